@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// condition is a key=value filter given with -where
+type condition struct {
+	key   string
+	value string
+}
+
+// conditions implements flag.Value for the repeatable -where flag
+type conditions []condition
+
+// String implements flag.Value
+func (c *conditions) String() string {
+	parts := make([]string, len(*c))
+	for i, cond := range *c {
+		parts[i] = cond.key + "=" + cond.value
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value
+func (c *conditions) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("invalid condition %q, expected key=value", s)
+	}
+	*c = append(*c, condition{key: key, value: value})
+	return nil
+}
+
+// filter selects the entries to render and the fields to keep
+type filter struct {
+	// minLevel, if set, drops entries below the level
+	minLevel *logger.Level
+	// since, if not zero, drops entries older than it
+	since time.Time
+	// where drops entries not matching every condition
+	where conditions
+	// fields, if set, are the only fields kept besides the standard ones
+	fields []string
+}
+
+// standardFields are always kept when projecting fields
+var standardFields = []string{
+	logger.DefaultTimestampFieldName,
+	logger.DefaultLevelFieldName,
+	logger.DefaultMessageFieldName,
+	logger.DefaultCallerFieldName,
+}
+
+// active reports whether the filter selects or modifies entries
+func (f *filter) active() bool {
+	return f.minLevel != nil || !f.since.IsZero() || len(f.where) > 0 || len(f.fields) > 0
+}
+
+// apply returns the entry to render, and false if it is filtered out
+func (f *filter) apply(line []byte) ([]byte, bool) {
+	if !f.active() {
+		return line, true
+	}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var entry map[string]any
+	if err := dec.Decode(&entry); err != nil {
+		return nil, false
+	}
+
+	if f.minLevel != nil {
+		s, _ := entry[logger.DefaultLevelFieldName].(string)
+		level, err := logger.ParseLevel(s)
+		if err != nil || level.Severity() < f.minLevel.Severity() {
+			return nil, false
+		}
+	}
+	if !f.since.IsZero() {
+		t, ok := entryTime(entry[logger.DefaultTimestampFieldName])
+		if !ok || t.Before(f.since) {
+			return nil, false
+		}
+	}
+	for _, cond := range f.where {
+		v, ok := entry[cond.key]
+		if !ok || fmt.Sprint(v) != cond.value {
+			return nil, false
+		}
+	}
+
+	if len(f.fields) == 0 {
+		return line, true
+	}
+	for key := range entry {
+		if !slices.Contains(standardFields, key) && !slices.Contains(f.fields, key) {
+			delete(entry, key)
+		}
+	}
+	projected, err := json.Marshal(entry)
+	if err != nil {
+		return nil, false
+	}
+	return projected, true
+}
+
+// entryTime parses an RFC 3339 timestamp or a Unix timestamp in seconds or milliseconds
+func entryTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		if n > 1e12 {
+			return time.UnixMilli(n), true
+		}
+		return time.Unix(n, 0), true
+	}
+	return time.Time{}, false
+}
+
+// parseSince parses a duration before now, such as "15m", or an RFC 3339 time
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -since %q, expected a duration or an RFC 3339 time", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,214 @@
+// Command elog renders easy-logger JSON logs in a human-readable format.
+//
+// Usage:
+//
+//	elog [flags] [file ...]
+//	elog tail [-f] [-n lines] [flags] file
+//
+// It reads the files given as arguments, or the standard input if there are
+// none, and renders each JSON entry with the logger's PrettyFormatter, with
+// colors by level. Entries spread over several lines, such as indented JSON,
+// are reassembled. Lines that are not JSON entries are printed unchanged,
+// unless entries are filtered:
+//
+//	go run ./cmd/server 2>&1 | elog
+//	elog -no-color app.log
+//
+// Entries can be filtered by level, age and field values, and projected to
+// some fields. Filtered entries can be printed as JSON for further processing:
+//
+//	elog -level warn -since 1h -where service=api -fields user,path app.log
+//	elog -json -where request_id=r42 app.log
+//
+// The tail command prints the last lines of a file and, with -f, follows it
+// as it grows, reopening it when it is rotated or truncated:
+//
+//	elog tail -f -level warn app.log
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "elog:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line and renders the inputs to stdout
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "tail" {
+		return runTail(ctx, args[1:], stdout)
+	}
+
+	flags := flag.NewFlagSet("elog", flag.ContinueOnError)
+	opts := addOptions(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	out, err := opts.output(stdout)
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return out.render(stdin)
+	}
+	for _, name := range flags.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = out.render(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// options are the rendering and filtering flags shared by the commands
+type options struct {
+	noColor    *bool
+	timeFormat *string
+	level      *string
+	since      *string
+	fields     *string
+	asJSON     *bool
+	where      conditions
+}
+
+// addOptions defines the shared flags on flags
+func addOptions(flags *flag.FlagSet) *options {
+	opts := &options{
+		noColor:    flags.Bool("no-color", os.Getenv("NO_COLOR") != "", "disable colors"),
+		timeFormat: flags.String("time-format", "15:04:05.000", "layout of the rendered timestamps"),
+		level:      flags.String("level", "", "only show entries at this level or above"),
+		since:      flags.String("since", "", "only show entries newer than a duration, such as 15m, or an RFC 3339 time"),
+		fields:     flags.String("fields", "", "comma-separated fields to show besides time, level, message and caller"),
+		asJSON:     flags.Bool("json", false, "print the entries as JSON instead of rendering them"),
+	}
+	flags.Var(&opts.where, "where", "only show entries with a field equal to a value, as key=value (repeatable)")
+	return opts
+}
+
+// output creates the output configured by the options
+func (opts *options) output(stdout io.Writer) (*output, error) {
+	out := &output{filter: filter{where: opts.where}, raw: stdout, pretty: lineWriter{stdout}}
+	if *opts.level != "" {
+		l, err := logger.ParseLevel(*opts.level)
+		if err != nil {
+			return nil, err
+		}
+		out.filter.minLevel = &l
+	}
+	if *opts.since != "" {
+		t, err := parseSince(*opts.since, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		out.filter.since = t
+	}
+	if *opts.fields != "" {
+		out.filter.fields = strings.Split(*opts.fields, ",")
+	}
+	if !*opts.asJSON {
+		out.pretty = logger.PrettyFormatter{
+			NoColor:     *opts.noColor,
+			TimeFormat:  *opts.timeFormat,
+			ExpandStack: true,
+		}.Format(stdout)
+	}
+	return out, nil
+}
+
+// output renders lines: JSON entries selected by the filter are written to
+// pretty, and the other lines to raw if no filter is active
+type output struct {
+	filter    filter
+	assembler assembler
+	pretty    io.Writer
+	raw       io.Writer
+}
+
+// render renders every line read from r
+func (out *output) render(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if err := out.line(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// line renders a line, once the entry it belongs to is complete
+func (out *output) line(line []byte) error {
+	for _, l := range out.assembler.add(line) {
+		if err := out.write(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush renders the lines of an incomplete entry
+func (out *output) flush() error {
+	for _, l := range out.assembler.flush() {
+		if err := out.write(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write renders a complete line
+func (out *output) write(line []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(line), []byte("{")) {
+		entry, ok := out.filter.apply(line)
+		if !ok {
+			return nil
+		}
+		if _, err := out.pretty.Write(entry); err == nil {
+			return nil
+		}
+	}
+	if out.filter.active() {
+		return nil
+	}
+	_, err := fmt.Fprintf(out.raw, "%s\n", line)
+	return err
+}
+
+// lineWriter writes each entry on its own line
+type lineWriter struct {
+	out io.Writer
+}
+
+// Write implements io.Writer
+func (w lineWriter) Write(p []byte) (int, error) {
+	if _, err := fmt.Fprintf(w.out, "%s\n", p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
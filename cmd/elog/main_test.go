@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRender tests rendering JSON entries and passing other lines through
+func TestRender(t *testing.T) {
+	input := strings.Join([]string{
+		`{"level":"info","service":"api","time":"2024-01-02T15:04:05Z","message":"started","port":8080}`,
+		`plain text line`,
+		`{"level":"error","time":"2024-01-02T15:04:06Z","caller":"/src/app/main.go:42","stack":[{"func":"main","source":"main.go","line":"42"}],"message":"failed"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"-no-color"}, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("run returned an error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected 4 lines, got %q", lines)
+	}
+	for i, want := range []string{"INF", "started", "port=8080"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("Expected line 0 to contain %q (%d), got %q", want, i, lines[0])
+		}
+	}
+	if lines[1] != "plain text line" {
+		t.Errorf("Expected the plain line unchanged, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "ERR") || !strings.Contains(lines[2], "main.go:42") || strings.Contains(lines[2], "stack=") {
+		t.Errorf("Unexpected error line %q", lines[2])
+	}
+	if strings.TrimSpace(lines[3]) != "at main (main.go:42)" {
+		t.Errorf("Expected the stack frame on its own line, got %q", lines[3])
+	}
+}
+
+// TestFilter tests filtering and projecting entries
+func TestFilter(t *testing.T) {
+	now := time.Now().UTC()
+	entry := func(level string, age time.Duration, extra string) string {
+		return `{"level":"` + level + `","time":"` + now.Add(-age).Format(time.RFC3339) + `",` + extra + `"message":"m"}`
+	}
+	input := strings.Join([]string{
+		entry("debug", time.Minute, `"service":"api","user":"a",`),
+		entry("warn", time.Minute, `"service":"api","user":"b","path":"/x",`),
+		entry("error", 2*time.Hour, `"service":"api","user":"c",`),
+		entry("error", time.Minute, `"service":"worker","user":"d",`),
+		`plain text line`,
+		entry("error", time.Minute, `"service":"api","user":"e","attempt":3,`),
+	}, "\n")
+
+	tests := []struct {
+		name  string
+		args  []string
+		users []string
+	}{
+		{"level", []string{"-level", "warn"}, []string{"b", "c", "d", "e"}},
+		{"since", []string{"-since", "1h"}, []string{"a", "b", "d", "e"}},
+		{"where", []string{"--where", "service=api", "--where", "attempt=3"}, []string{"e"}},
+		{"combined", []string{"-level", "warn", "-since", "1h", "-where", "service=api"}, []string{"b", "e"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(context.Background(), append([]string{"-json"}, tt.args...), strings.NewReader(input), &out); err != nil {
+				t.Fatalf("run returned an error: %v", err)
+			}
+			var users []string
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var e map[string]any
+				if err := json.Unmarshal([]byte(line), &e); err != nil {
+					t.Fatalf("Expected JSON output, got %q", line)
+				}
+				users = append(users, e["user"].(string))
+			}
+			if strings.Join(users, ",") != strings.Join(tt.users, ",") {
+				t.Errorf("Expected users %v, got %v", tt.users, users)
+			}
+		})
+	}
+
+	// Projection keeps the standard fields and the listed ones
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"-json", "-fields", "user", "-where", "user=b"}, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("run returned an error: %v", err)
+	}
+	var e map[string]any
+	json.Unmarshal(out.Bytes(), &e)
+	if len(e) != 4 || e["user"] != "b" || e["message"] != "m" {
+		t.Errorf("Unexpected projected entry: %s", out.String())
+	}
+
+	if err := run(context.Background(), []string{"-since", "yesterday"}, strings.NewReader(input), &out); err == nil {
+		t.Error("Expected an error for an invalid -since")
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"time"
+)
+
+// maxEntryLines limits the lines reassembled into a single entry
+const maxEntryLines = 1000
+
+// pollInterval is how often a followed file is checked for new data
+const pollInterval = 200 * time.Millisecond
+
+// tailChunkSize is how much of the file is read at a time, backwards from its
+// end, to find the last lines
+var tailChunkSize int64 = 64 << 10
+
+// assembler joins the lines of entries spread over several lines, such as
+// indented JSON with multi-line stacks, into single-line entries
+type assembler struct {
+	lines [][]byte
+}
+
+// add adds a line and returns the lines ready to be rendered
+func (a *assembler) add(line []byte) [][]byte {
+	line = bytes.Clone(line)
+	var ready [][]byte
+	if len(a.lines) > 0 && bytes.HasPrefix(line, []byte("{")) {
+		// Entries start at the beginning of a line, while the lines inside an
+		// entry are indented: the pending lines were an entry cut short
+		ready = a.flush()
+	}
+	if len(a.lines) == 0 {
+		trimmed := bytes.TrimSpace(line)
+		if !bytes.HasPrefix(trimmed, []byte("{")) || json.Valid(trimmed) {
+			return append(ready, line)
+		}
+	}
+	a.lines = append(a.lines, line)
+	joined := bytes.Join(a.lines, []byte("\n"))
+	if json.Valid(joined) {
+		a.lines = nil
+		var compact bytes.Buffer
+		json.Compact(&compact, joined)
+		return append(ready, compact.Bytes())
+	}
+	if len(a.lines) >= maxEntryLines {
+		return append(ready, a.flush()...)
+	}
+	return ready
+}
+
+// flush returns the lines of an incomplete entry as they are
+func (a *assembler) flush() [][]byte {
+	lines := a.lines
+	a.lines = nil
+	return lines
+}
+
+// runTail parses the tail command line and prints the file
+func runTail(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("elog tail", flag.ContinueOnError)
+	follow := flags.Bool("f", false, "follow the file as it grows, across rotations")
+	lines := flags.Int("n", 10, "number of lines to print from the end of the file")
+	opts := addOptions(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("tail expects a single file")
+	}
+	out, err := opts.output(stdout)
+	if err != nil {
+		return err
+	}
+
+	f, err := openFollower(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.close()
+	if err := f.last(*lines, out.line); err != nil {
+		return err
+	}
+	if !*follow {
+		return out.flush()
+	}
+	return f.follow(ctx, pollInterval, out.line)
+}
+
+// follower reads the lines appended to a file, reopening the path when the
+// file is rotated and starting over when it is truncated
+type follower struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial []byte
+}
+
+// openFollower opens the file at path
+func openFollower(path string) (*follower, error) {
+	f := &follower{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the path, replacing the current file
+func (f *follower) open() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.close()
+	f.file, f.info, f.offset, f.partial = file, info, 0, nil
+	return nil
+}
+
+// close closes the current file
+func (f *follower) close() {
+	if f.file != nil {
+		f.file.Close()
+	}
+}
+
+// last emits the last n complete lines of the file and moves to its end. The
+// file is read backwards from its end until it has n lines, so large files are
+// not read whole.
+func (f *follower) last(n int, emit func([]byte) error) error {
+	size, err := f.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	f.offset = size
+	var data []byte
+	for start := size; start > 0 && bytes.Count(data, []byte("\n")) <= n; {
+		chunk := make([]byte, min(tailChunkSize, start))
+		start -= int64(len(chunk))
+		if _, err := f.file.ReadAt(chunk, start); err != nil {
+			return err
+		}
+		data = append(chunk, data...)
+	}
+	end := bytes.LastIndexByte(data, '\n') + 1
+	f.partial = bytes.Clone(data[end:])
+	lines := bytes.SplitAfter(data[:end], []byte("\n"))
+	lines = lines[:len(lines)-1]
+	for _, line := range lines[max(0, len(lines)-n):] {
+		if err := emit(bytes.TrimSuffix(line, []byte("\n"))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// follow emits the lines appended to the file until ctx is done
+func (f *follower) follow(ctx context.Context, interval time.Duration, emit func([]byte) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := f.poll(emit); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll emits the complete lines appended since the last poll, then checks
+// whether the file was rotated or truncated
+func (f *follower) poll(emit func([]byte) error) error {
+	if err := f.read(emit); err != nil {
+		return err
+	}
+	info, err := os.Stat(f.path)
+	if err != nil {
+		// The file may be missing for a moment during a rotation
+		return nil
+	}
+	switch {
+	case !os.SameFile(info, f.info):
+		if len(f.partial) > 0 {
+			if err := emit(f.partial); err != nil {
+				return err
+			}
+		}
+		if err := f.open(); err != nil {
+			return nil
+		}
+		return f.read(emit)
+	case info.Size() < f.offset:
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		f.offset, f.partial = 0, nil
+		return f.read(emit)
+	}
+	return nil
+}
+
+// read emits the complete lines available in the current file
+func (f *follower) read(emit func([]byte) error) error {
+	data, err := io.ReadAll(f.file)
+	if err != nil {
+		return err
+	}
+	f.offset += int64(len(data))
+	data = append(f.partial, data...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if err := emit(data[:i]); err != nil {
+			return err
+		}
+		data = data[i+1:]
+	}
+	f.partial = bytes.Clone(data)
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAssembler tests reassembling entries spread over several lines
+func TestAssembler(t *testing.T) {
+	var a assembler
+	var got []string
+	for _, line := range []string{
+		`{"level":"info","message":"single"}`,
+		`{`,
+		`  "level": "error",`,
+		`  "stack": [`,
+		`    {"func": "main", "line": "42"}`,
+		`  ],`,
+		`  "message": "multi"`,
+		`}`,
+		`plain`,
+	} {
+		for _, l := range a.add([]byte(line)) {
+			got = append(got, string(l))
+		}
+	}
+	want := []string{
+		`{"level":"info","message":"single"}`,
+		`{"level":"error","stack":[{"func":"main","line":"42"}],"message":"multi"}`,
+		`plain`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	a.add([]byte(`{ "incomplete": true,`))
+	if lines := a.flush(); len(lines) != 1 {
+		t.Errorf("Expected the incomplete entry to be flushed, got %q", lines)
+	}
+
+	// A truncated entry is flushed as soon as the next entry starts
+	a.add([]byte(`{"level":"info","mess`))
+	lines := a.add([]byte(`{"level":"info","message":"next"}`))
+	if len(lines) != 2 || string(lines[0]) != `{"level":"info","mess` || string(lines[1]) != `{"level":"info","message":"next"}` {
+		t.Errorf("Expected the truncated entry and the next one, got %q", lines)
+	}
+	a.add([]byte(`{"level":"info","mess`))
+	if lines := a.add([]byte(`{`)); len(lines) != 1 || len(a.lines) != 1 {
+		t.Errorf("Expected the truncated entry flushed and a new entry pending, got %q", lines)
+	}
+}
+
+// TestFollower tests following a file across partial writes, rotation and truncation
+func TestFollower(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	write := func(name, data string) {
+		t.Helper()
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.WriteString(data)
+		f.Close()
+	}
+	write(path, "old 1\nold 2\nold 3\npart")
+
+	f, err := openFollower(path)
+	if err != nil {
+		t.Fatalf("openFollower returned an error: %v", err)
+	}
+	defer f.close()
+	var got []string
+	emit := func(line []byte) error {
+		got = append(got, string(line))
+		return nil
+	}
+
+	f.last(2, emit)
+	write(path, "ial\n")
+	f.poll(emit)
+
+	// Rotation: the old file is renamed and a new one created
+	write(path, "before rotation\n")
+	os.Rename(path, path+".1")
+	write(path, "after rotation\n")
+	f.poll(emit)
+
+	// Truncation
+	os.Truncate(path, 0)
+	write(path, "truncated\n")
+	f.poll(emit)
+
+	want := []string{"old 2", "old 3", "partial", "before rotation", "after rotation", "truncated"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestFollowerLast tests reading the last lines backwards in several chunks
+func TestFollowerLast(t *testing.T) {
+	defer func(size int64) { tailChunkSize = size }(tailChunkSize)
+	tailChunkSize = 4
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("first line\nsecond line\nthird line\nfourth line\npart"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := openFollower(path)
+	if err != nil {
+		t.Fatalf("openFollower returned an error: %v", err)
+	}
+	defer f.close()
+
+	var got []string
+	for _, n := range []int{2, 10} {
+		got = got[:0]
+		err := f.last(n, func(line []byte) error {
+			got = append(got, string(line))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("last returned an error: %v", err)
+		}
+		want := []string{"third line", "fourth line"}
+		if n == 10 {
+			want = []string{"first line", "second line", "third line", "fourth line"}
+		}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("last(%d): expected %q, got %q", n, want, got)
+		}
+		if string(f.partial) != "part" || f.offset != 50 {
+			t.Errorf("Expected the partial line and the end offset, got %q at %d", f.partial, f.offset)
+		}
+	}
+}
+
+// TestTail tests the tail command without follow
+func TestTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	os.WriteFile(path, []byte(strings.Join([]string{
+		`{"level":"info","message":"first"}`,
+		`{"level":"warn","message":"second"}`,
+		`{"level":"error","message":"third"}`,
+	}, "\n")+"\n"), 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"tail", "-n", "2", "-json", "-level", "error", path}, nil, &out); err != nil {
+		t.Fatalf("run returned an error: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != `{"level":"error","message":"third"}` {
+		t.Errorf("Unexpected output: %s", out.String())
+	}
+}
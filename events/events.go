@@ -0,0 +1,200 @@
+// Package events declares the event types of an application once, with the
+// fields they require, and logs them with validation:
+//
+//	registry := events.NewRegistry()
+//	registry.Register(events.Type{Name: "user_signup", Required: []string{"plan"}})
+//
+//	l := events.New(log, registry)
+//	l.Event("user_signup").Str("plan", "pro").Emit()
+//
+// Events are written at info level, with the event name as message and in the
+// "event" field. Entries of unknown events or missing required fields are
+// annotated with the violations, or dropped in logger.SchemaReject mode.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// EventFieldName is the key of the event name
+const EventFieldName = logger.EventFieldName
+
+// ErrUnknownEvent is returned when emitting an event not registered
+var ErrUnknownEvent = errors.New("events: unknown event")
+
+// ErrDuplicateEvent is returned when registering an event name twice
+var ErrDuplicateEvent = errors.New("events: event already registered")
+
+// ErrInvalidEvent is returned by Emit when the event misses required fields
+var ErrInvalidEvent = errors.New("events: invalid event")
+
+// Type declares an event
+type Type struct {
+	// Name identifies the event
+	Name string
+	// Required lists the fields every event of the type must contain
+	Required []string
+}
+
+// Registry holds the declared event types
+type Registry struct {
+	// Mode sets what happens to invalid events, annotated by default
+	Mode logger.SchemaMode
+
+	mu    sync.RWMutex
+	types map[string]Type
+}
+
+// NewRegistry creates an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]Type)}
+}
+
+// Register declares an event type. It returns ErrDuplicateEvent if the name
+// is already registered.
+func (r *Registry) Register(t Type) error {
+	if t.Name == "" {
+		return errors.New("events: empty event name")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[t.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateEvent, t.Name)
+	}
+	r.types[t.Name] = t
+	return nil
+}
+
+// Lookup returns the event type registered with the name
+func (r *Registry) Lookup(name string) (Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Logger logs the events of a registry
+type Logger struct {
+	*logger.Logger
+	registry *Registry
+}
+
+// New creates a Logger writing the events of the registry to l
+func New(l *logger.Logger, registry *Registry) *Logger {
+	return &Logger{Logger: l, registry: registry}
+}
+
+// Event starts an event, finished with Emit
+func (l *Logger) Event(name string) *Event {
+	t, known := l.registry.Lookup(name)
+	return &Event{
+		name:  name,
+		typ:   t,
+		known: known,
+		mode:  l.registry.Mode,
+		lb:    l.Info().CallerSkipFrame(1).Str(EventFieldName, name),
+	}
+}
+
+// Event is an event being built. Its methods add fields like the ones of
+// logger.LogBuilder, recording the keys for validation.
+type Event struct {
+	name  string
+	typ   Type
+	known bool
+	mode  logger.SchemaMode
+	lb    *logger.LogBuilder
+	keys  []string
+}
+
+// add records the key of a field
+func (e *Event) add(key string) *Event {
+	e.keys = append(e.keys, key)
+	return e
+}
+
+// Str adds a string field
+func (e *Event) Str(key, value string) *Event {
+	e.lb.Str(key, value)
+	return e.add(key)
+}
+
+// Int adds an integer field
+func (e *Event) Int(key string, value int) *Event {
+	e.lb.Int(key, value)
+	return e.add(key)
+}
+
+// Int64 adds an int64 field
+func (e *Event) Int64(key string, value int64) *Event {
+	e.lb.Int64(key, value)
+	return e.add(key)
+}
+
+// Float64 adds a float64 field
+func (e *Event) Float64(key string, value float64) *Event {
+	e.lb.Float64(key, value)
+	return e.add(key)
+}
+
+// Bool adds a boolean field
+func (e *Event) Bool(key string, value bool) *Event {
+	e.lb.Bool(key, value)
+	return e.add(key)
+}
+
+// Dur adds a duration field
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	e.lb.Dur(key, value)
+	return e.add(key)
+}
+
+// Any adds a field of any type
+func (e *Event) Any(key string, value any) *Event {
+	e.lb.AddField(key, value)
+	return e.add(key)
+}
+
+// validate returns the violations of the event type
+func (e *Event) validate() []string {
+	if !e.known {
+		return []string{fmt.Sprintf("unknown event %q", e.name)}
+	}
+	var violations []string
+	for _, key := range e.typ.Required {
+		if !slices.Contains(e.keys, key) {
+			violations = append(violations, fmt.Sprintf("missing required field %q", key))
+		}
+	}
+	return violations
+}
+
+// Emit validates and writes the event. It returns ErrUnknownEvent or
+// ErrInvalidEvent if the event does not conform to its type; the entry is then
+// annotated with the violations, or dropped in logger.SchemaReject mode.
+func (e *Event) Emit() error {
+	violations := e.validate()
+	if len(violations) > 0 {
+		if e.mode == logger.SchemaReject {
+			e.lb.When(false)
+		} else {
+			e.lb.AddField(logger.SchemaErrorsFieldName, violations)
+		}
+	}
+	e.lb.Msg(e.name)
+
+	switch {
+	case !e.known:
+		return fmt.Errorf("%w: %q", ErrUnknownEvent, e.name)
+	case len(violations) > 0:
+		return fmt.Errorf("%w: %s", ErrInvalidEvent, strings.Join(violations, ", "))
+	}
+	return nil
+}
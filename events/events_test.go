@@ -0,0 +1,88 @@
+package events
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+	"github.com/jdroa1998/easy-logger/loggertest"
+)
+
+// newTestLogger creates an events Logger recording its entries, with the
+// user_signup event registered
+func newTestLogger(t *testing.T, mode logger.SchemaMode, opts ...logger.Option) (*Logger, *loggertest.TestLogger) {
+	t.Helper()
+	registry := NewRegistry()
+	registry.Mode = mode
+	if err := registry.Register(Type{Name: "user_signup", Required: []string{"plan", "user"}}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	tl := loggertest.New(opts...)
+	return New(tl.Logger, registry), tl
+}
+
+// TestEmit tests emitting valid and invalid events
+func TestEmit(t *testing.T) {
+	l, tl := newTestLogger(t, logger.SchemaAnnotate)
+
+	if err := l.Event("user_signup").Str("plan", "pro").Int("user", 42).Emit(); err != nil {
+		t.Fatalf("Emit failed: %v", err)
+	}
+	tl.AssertLogged(t, logger.InfoLevel, "user_signup",
+		loggertest.Field(EventFieldName, "user_signup"),
+		loggertest.Field("plan", "pro"),
+		loggertest.Field("user", 42))
+
+	err := l.Event("user_signup").Str("plan", "pro").Emit()
+	if !errors.Is(err, ErrInvalidEvent) || !strings.Contains(err.Error(), `"user"`) {
+		t.Errorf("Expected a missing field error, got %v", err)
+	}
+	tl.AssertLogged(t, logger.InfoLevel, "user_signup",
+		loggertest.Field(logger.SchemaErrorsFieldName, []string{`missing required field "user"`}))
+
+	if err := l.Event("user_login").Emit(); !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("Expected an unknown event error, got %v", err)
+	}
+	tl.AssertLogged(t, logger.InfoLevel, "user_login", loggertest.HasField(logger.SchemaErrorsFieldName))
+}
+
+// TestEmitReject tests dropping invalid events
+func TestEmitReject(t *testing.T) {
+	l, tl := newTestLogger(t, logger.SchemaReject)
+
+	if err := l.Event("user_signup").Str("plan", "pro").Emit(); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("Expected a missing field error, got %v", err)
+	}
+	if len(tl.Entries()) != 0 {
+		t.Errorf("Expected the invalid event to be dropped, got %v", tl.Entries())
+	}
+}
+
+// TestEmitCaller tests that the caller is the code emitting the event
+func TestEmitCaller(t *testing.T) {
+	l, tl := newTestLogger(t, logger.SchemaAnnotate, logger.WithCaller(true))
+
+	l.Event("user_signup").Str("plan", "pro").Int("user", 1).Emit()
+	caller, _ := tl.Entries()[0].Fields[logger.DefaultCallerFieldName].(string)
+	if !strings.Contains(caller, "events_test.go") {
+		t.Errorf("Expected the caller in the test, got %q", caller)
+	}
+}
+
+// TestRegister tests registering event types
+func TestRegister(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(Type{Name: "order_placed"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := registry.Register(Type{Name: "order_placed"}); !errors.Is(err, ErrDuplicateEvent) {
+		t.Errorf("Expected a duplicate error, got %v", err)
+	}
+	if err := registry.Register(Type{}); err == nil {
+		t.Error("Expected an error for an empty name")
+	}
+	if _, ok := registry.Lookup("order_placed"); !ok {
+		t.Error("Expected the registered event")
+	}
+}
@@ -0,0 +1,133 @@
+package logger
+
+import "time"
+
+// Canonical access log field names.
+const (
+	AccessMethodFieldName    = "method"
+	AccessPathFieldName      = "path"
+	AccessProtocolFieldName  = "protocol"
+	AccessStatusFieldName    = "status"
+	AccessLatencyFieldName   = "latency"
+	AccessBytesFieldName     = "bytes"
+	AccessRemoteIPFieldName  = "remote_ip"
+	AccessUserAgentFieldName = "user_agent"
+	AccessRequestIDFieldName = "request_id"
+)
+
+// AccessMessage is the message of access log entries
+const AccessMessage = "access"
+
+// AccessEvent builds an access log entry with a consistent set of fields,
+// usable by HTTP and gRPC servers alike:
+//
+//	log.Access().Method("GET").Path("/users").Status(200).Latency(d).Send()
+//
+// The level is chosen from the status when the entry is sent: error for 5xx,
+// warn for 4xx and info otherwise.
+type AccessEvent struct {
+	logger    *Logger
+	method    string
+	path      string
+	protocol  string
+	status    int
+	latency   time.Duration
+	bytes     int64
+	remoteIP  string
+	userAgent string
+	requestID string
+}
+
+// Access starts a new access log entry
+func (l *Logger) Access() *AccessEvent {
+	return &AccessEvent{logger: l}
+}
+
+// Method sets the request method, e.g. GET or the gRPC method name
+func (a *AccessEvent) Method(method string) *AccessEvent {
+	a.method = method
+	return a
+}
+
+// Path sets the request path
+func (a *AccessEvent) Path(path string) *AccessEvent {
+	a.path = path
+	return a
+}
+
+// Protocol sets the request protocol, e.g. HTTP/1.1 or grpc
+func (a *AccessEvent) Protocol(protocol string) *AccessEvent {
+	a.protocol = protocol
+	return a
+}
+
+// Status sets the response status code
+func (a *AccessEvent) Status(status int) *AccessEvent {
+	a.status = status
+	return a
+}
+
+// Latency sets the time taken to serve the request
+func (a *AccessEvent) Latency(d time.Duration) *AccessEvent {
+	a.latency = d
+	return a
+}
+
+// Bytes sets the size of the response body
+func (a *AccessEvent) Bytes(n int64) *AccessEvent {
+	a.bytes = n
+	return a
+}
+
+// RemoteIP sets the client address
+func (a *AccessEvent) RemoteIP(ip string) *AccessEvent {
+	a.remoteIP = ip
+	return a
+}
+
+// UserAgent sets the client user agent
+func (a *AccessEvent) UserAgent(userAgent string) *AccessEvent {
+	a.userAgent = userAgent
+	return a
+}
+
+// RequestID sets the request identifier
+func (a *AccessEvent) RequestID(id string) *AccessEvent {
+	a.requestID = id
+	return a
+}
+
+// Send writes the access log entry
+func (a *AccessEvent) Send() {
+	var lb *LogBuilder
+	switch {
+	case a.status >= 500:
+		lb = a.logger.Error()
+	case a.status >= 400:
+		lb = a.logger.Warn()
+	default:
+		lb = a.logger.Info()
+	}
+	if lb == nil {
+		return
+	}
+
+	lb.Str(AccessMethodFieldName, a.method).
+		Str(AccessPathFieldName, a.path)
+	if a.protocol != "" {
+		lb.Str(AccessProtocolFieldName, a.protocol)
+	}
+	lb.Int(AccessStatusFieldName, a.status).
+		Dur(AccessLatencyFieldName, a.latency).
+		Int64(AccessBytesFieldName, a.bytes)
+	if a.remoteIP != "" {
+		lb.Str(AccessRemoteIPFieldName, a.remoteIP)
+	}
+	if a.userAgent != "" {
+		lb.Str(AccessUserAgentFieldName, a.userAgent)
+	}
+	if a.requestID != "" {
+		lb.Str(AccessRequestIDFieldName, a.requestID)
+	}
+	lb.Msg(AccessMessage)
+}
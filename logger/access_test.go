@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// TestAccessEvent tests the access log entry fields and levels
+func TestAccessEvent(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	log.Access().
+		Method("GET").
+		Path("/users").
+		Protocol("HTTP/1.1").
+		Status(200).
+		Latency(25 * time.Millisecond).
+		Bytes(512).
+		RemoteIP("10.0.0.1").
+		UserAgent("curl/8.0").
+		RequestID("req-1").
+		Send()
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"method":"GET","path":"/users","protocol":"HTTP/1.1","status":200,"latency":25,"bytes":512`, "info")
+	assertLogContains(t, logData, `"remote_ip":"10.0.0.1","user_agent":"curl/8.0","request_id":"req-1"`, "")
+	assertLogContains(t, logData, `"message":"access"`, "")
+
+	buf.Reset()
+	log.Access().Method("POST").Path("/users").Status(404).Send()
+	assertLogContains(t, buf.String(), `"status":404`, "warn")
+	assertLogNotContains(t, buf.String(), "remote_ip")
+
+	buf.Reset()
+	log.Access().Method("POST").Path("/users").Status(503).Send()
+	assertLogContains(t, buf.String(), `"status":503`, "error")
+}
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when writing to a closed writer
+var ErrClosed = errors.New("logger: writer is closed")
+
+// Default limits used by NewBatcher for zero BatchConfig values.
+const (
+	DefaultBatchMaxEntries = 100
+	DefaultBatchMaxBytes   = 1 << 20
+	DefaultBatchMaxLatency = time.Second
+)
+
+// BatchConfig sets when a Batcher flushes its pending entries
+type BatchConfig struct {
+	// MaxEntries flushes once this many entries are pending
+	MaxEntries int
+	// MaxBytes flushes once the pending entries reach this size
+	MaxBytes int
+	// MaxLatency flushes entries that have been pending for this long
+	MaxLatency time.Duration
+	// OnError is called with the errors of flushes triggered by MaxLatency,
+	// which have no caller to return them to
+	OnError func(error)
+}
+
+// BatchFunc sends a batch of entries to a sink. Each entry is a complete
+// serialized log entry including its trailing newline. The batch must not be
+// retained after the function returns.
+type BatchFunc func(batch [][]byte) error
+
+// Batcher is an io.Writer that groups entries into batches for network sinks,
+// flushing when the batch is full or the oldest entry has waited MaxLatency.
+// Use it as the logger output:
+//
+//	b := logger.NewBatcher(sendToSink, logger.BatchConfig{MaxEntries: 500})
+//	defer b.Close()
+//	log := logger.NewWithOptions(logger.WithOutput(b))
+type Batcher struct {
+	flush BatchFunc
+	cfg   BatchConfig
+
+	mu      sync.Mutex
+	pending [][]byte
+	size    int
+	timer   *time.Timer
+	closed  bool
+
+	// flushMu serializes calls to flush, keeping batches in order
+	flushMu sync.Mutex
+
+	healthMu sync.Mutex
+	lastErr  error
+}
+
+// NewBatcher creates a Batcher that sends batches with flush
+func NewBatcher(flush BatchFunc, cfg BatchConfig) *Batcher {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultBatchMaxEntries
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultBatchMaxBytes
+	}
+	if cfg.MaxLatency <= 0 {
+		cfg.MaxLatency = DefaultBatchMaxLatency
+	}
+	return &Batcher{flush: flush, cfg: cfg}
+}
+
+// NewBatcherContext creates a Batcher like NewBatcher that is closed, flushing
+// the pending entries, once ctx is done. Close errors are passed to OnError.
+func NewBatcherContext(ctx context.Context, flush BatchFunc, cfg BatchConfig) *Batcher {
+	b := NewBatcher(flush, cfg)
+	closeOnDone(ctx, b, b.cfg.OnError)
+	return b
+}
+
+// Write implements io.Writer. The entry is copied, and the batch is flushed
+// synchronously if it is full.
+func (b *Batcher) Write(p []byte) (int, error) {
+	entry := append([]byte(nil), p...)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return 0, ErrClosed
+	}
+	b.pending = append(b.pending, entry)
+	b.size += len(entry)
+	if len(b.pending) < b.cfg.MaxEntries && b.size < b.cfg.MaxBytes {
+		if b.timer == nil {
+			b.timer = time.AfterFunc(b.cfg.MaxLatency, b.flushOnTimer)
+		}
+		b.mu.Unlock()
+		return len(p), nil
+	}
+	if err := b.sendLocked(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush sends the pending entries immediately
+func (b *Batcher) Flush() error {
+	b.mu.Lock()
+	return b.sendLocked()
+}
+
+// Close flushes the pending entries and stops accepting new ones
+func (b *Batcher) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	return b.sendLocked()
+}
+
+// flushOnTimer flushes the pending entries once MaxLatency has elapsed
+func (b *Batcher) flushOnTimer() {
+	if err := b.Flush(); err != nil && b.cfg.OnError != nil {
+		b.cfg.OnError(err)
+	}
+}
+
+// sendLocked removes the pending entries and passes them to the flush
+// function. b.mu must be held and is released; flushMu is acquired before
+// releasing it so batches are sent in the order they were taken.
+func (b *Batcher) sendLocked() error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	b.size = 0
+
+	b.flushMu.Lock()
+	b.mu.Unlock()
+	defer b.flushMu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+	err := b.flush(batch)
+	b.healthMu.Lock()
+	b.lastErr = err
+	b.healthMu.Unlock()
+	return err
+}
+
+// Health implements HealthChecker. It returns a *SinkError with the number of
+// pending entries if the last flush failed.
+func (b *Batcher) Health() error {
+	b.healthMu.Lock()
+	err := b.lastErr
+	b.healthMu.Unlock()
+	if err == nil {
+		return nil
+	}
+	b.mu.Lock()
+	backlog := len(b.pending)
+	b.mu.Unlock()
+	return &SinkError{Err: err, Backlog: backlog}
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder records the batches sent by a Batcher
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]string
+	err     error
+}
+
+func (r *batchRecorder) flush(batch [][]byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]string, len(batch))
+	for i, e := range batch {
+		entries[i] = string(e)
+	}
+	r.batches = append(r.batches, entries)
+	return r.err
+}
+
+func (r *batchRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.batches)
+}
+
+// TestBatcherMaxEntries tests flushing when the batch is full
+func TestBatcherMaxEntries(t *testing.T) {
+	rec := &batchRecorder{}
+	b := NewBatcher(rec.flush, BatchConfig{MaxEntries: 2, MaxLatency: time.Hour})
+	log := New(Config{Output: b, WithCaller: false})
+
+	log.InfoMsg("first")
+	if rec.count() != 0 {
+		t.Fatal("Batch should not be flushed before it is full")
+	}
+	log.InfoMsg("second")
+	log.InfoMsg("third")
+	if rec.count() != 1 || len(rec.batches[0]) != 2 {
+		t.Fatalf("Expected one batch of 2 entries, got %v", rec.batches)
+	}
+	assertLogContains(t, rec.batches[0][1], "second", "info")
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+	if rec.count() != 2 || len(rec.batches[1]) != 1 {
+		t.Fatalf("Close should flush the pending entry, got %v", rec.batches)
+	}
+	if _, err := b.Write([]byte("{}\n")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed after Close, got %v", err)
+	}
+}
+
+// TestBatcherMaxBytes tests flushing when the batch reaches its size limit
+func TestBatcherMaxBytes(t *testing.T) {
+	rec := &batchRecorder{err: errors.New("sink unavailable")}
+	b := NewBatcher(rec.flush, BatchConfig{MaxBytes: 10, MaxLatency: time.Hour})
+
+	if _, err := b.Write([]byte("0123456789\n")); err == nil {
+		t.Error("Expected the flush error to be returned by Write")
+	}
+	if rec.count() != 1 {
+		t.Errorf("Expected a flush once MaxBytes is reached, got %d", rec.count())
+	}
+}
+
+// TestBatcherMaxLatency tests flushing pending entries after MaxLatency
+func TestBatcherMaxLatency(t *testing.T) {
+	rec := &batchRecorder{}
+	b := NewBatcher(rec.flush, BatchConfig{MaxEntries: 100, MaxLatency: 10 * time.Millisecond})
+	defer b.Close()
+
+	b.Write([]byte("{}\n"))
+	deadline := time.Now().Add(time.Second)
+	for rec.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if rec.count() != 1 {
+		t.Errorf("Expected the pending entry to be flushed after MaxLatency")
+	}
+}
+
+// TestBatcherContext tests closing a batcher when its context is cancelled
+func TestBatcherContext(t *testing.T) {
+	rec := &batchRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := NewBatcherContext(ctx, rec.flush, BatchConfig{MaxEntries: 100, MaxLatency: time.Hour})
+
+	b.Write([]byte("{}\n"))
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for rec.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if rec.count() != 1 {
+		t.Fatal("Expected the pending entry to be flushed when the context is cancelled")
+	}
+	if _, err := b.Write([]byte("{}\n")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected the batcher to be closed, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for batches skipped while a circuit breaker is open
+var ErrCircuitOpen = errors.New("logger: circuit breaker is open")
+
+// Default values used by NewCircuitBreaker for zero BreakerConfig values
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCoolDown         = 30 * time.Second
+)
+
+// BreakerConfig sets when a CircuitBreaker skips its sink
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit
+	FailureThreshold int
+	// CoolDown is how long the sink is skipped once the circuit opens. The next
+	// batch after it is sent as a trial, which closes the circuit if it succeeds
+	CoolDown time.Duration
+	// Fallback, if set, receives the batches skipped or failed while the circuit is open
+	Fallback BatchFunc
+}
+
+// CircuitBreaker stops sending to a persistently failing sink for a cool-down
+// period, routing the batches to a fallback instead of waiting on the sink
+// for every batch:
+//
+//	cb := logger.NewCircuitBreaker(sendToSink, logger.BreakerConfig{Fallback: sendToFile})
+//	b := logger.NewBatcher(cb.Send, logger.BatchConfig{})
+type CircuitBreaker struct {
+	flush BatchFunc
+	cfg   BreakerConfig
+	now   func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker creates a CircuitBreaker around the sink
+func NewCircuitBreaker(flush BatchFunc, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = DefaultBreakerCoolDown
+	}
+	return &CircuitBreaker{flush: flush, cfg: cfg, now: time.Now}
+}
+
+// Send is a BatchFunc that sends the batch to the sink, or to the fallback
+// while the circuit is open
+func (cb *CircuitBreaker) Send(batch [][]byte) error {
+	if cb.isOpen() {
+		return cb.fallback(batch, ErrCircuitOpen)
+	}
+	err := cb.flush(batch)
+
+	cb.mu.Lock()
+	if err == nil {
+		cb.failures = 0
+	} else if cb.failures++; cb.failures >= cb.cfg.FailureThreshold {
+		cb.openUntil = cb.now().Add(cb.cfg.CoolDown)
+	}
+	cb.mu.Unlock()
+
+	if err != nil {
+		return cb.fallback(batch, err)
+	}
+	return nil
+}
+
+// Health implements HealthChecker, returning ErrCircuitOpen while the circuit is open
+func (cb *CircuitBreaker) Health() error {
+	if cb.isOpen() {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// isOpen reports whether the sink is being skipped
+func (cb *CircuitBreaker) isOpen() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.now().Before(cb.openUntil)
+}
+
+// fallback sends the batch to the fallback sink if there is one, or returns err
+func (cb *CircuitBreaker) fallback(batch [][]byte, err error) error {
+	if cb.cfg.Fallback == nil {
+		return err
+	}
+	return cb.cfg.Fallback(batch)
+}
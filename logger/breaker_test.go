@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests skipping a failing sink during the cool-down
+func TestCircuitBreaker(t *testing.T) {
+	down := errors.New("unavailable")
+	sink := &batchRecorder{err: down}
+	fallback := &batchRecorder{}
+	cb := NewCircuitBreaker(sink.flush, BreakerConfig{
+		FailureThreshold: 2,
+		CoolDown:         time.Minute,
+		Fallback:         fallback.flush,
+	})
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+	batch := [][]byte{[]byte("entry\n")}
+
+	// Failures reach the fallback until the threshold opens the circuit
+	for range 2 {
+		if err := cb.Send(batch); err != nil {
+			t.Fatalf("Expected the fallback to succeed, got %v", err)
+		}
+	}
+	if !errors.Is(cb.Health(), ErrCircuitOpen) {
+		t.Fatal("Expected the circuit to be open")
+	}
+
+	// The sink is skipped while the circuit is open
+	cb.Send(batch)
+	if sink.count() != 2 || fallback.count() != 3 {
+		t.Errorf("Expected 2 sink and 3 fallback batches, got %d and %d", sink.count(), fallback.count())
+	}
+
+	// After the cool-down a successful trial closes the circuit
+	now = now.Add(time.Minute)
+	sink.mu.Lock()
+	sink.err = nil
+	sink.mu.Unlock()
+	if err := cb.Send(batch); err != nil || cb.Health() != nil {
+		t.Errorf("Expected the circuit to close, got %v", err)
+	}
+	if sink.count() != 3 {
+		t.Errorf("Expected the trial batch to reach the sink, got %d", sink.count())
+	}
+}
+
+// TestCircuitBreakerNoFallback tests the errors returned without a fallback
+func TestCircuitBreakerNoFallback(t *testing.T) {
+	down := errors.New("unavailable")
+	cb := NewCircuitBreaker(func([][]byte) error { return down }, BreakerConfig{FailureThreshold: 1})
+
+	if err := cb.Send(nil); !errors.Is(err, down) {
+		t.Errorf("Expected the sink error, got %v", err)
+	}
+	if err := cb.Send(nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+}
@@ -29,6 +29,31 @@ func (b *LoggerBuilder) WithPrettyPrint(enabled bool) *LoggerBuilder {
 	return b
 }
 
+// WithFormat sets the output format, taking precedence over WithPrettyPrint
+func (b *LoggerBuilder) WithFormat(format Format) *LoggerBuilder {
+	b.config.Format = format
+	return b
+}
+
+// WithPalette sets the level colors of the pretty format
+func (b *LoggerBuilder) WithPalette(palette Palette) *LoggerBuilder {
+	b.config.Palette = palette
+	return b
+}
+
+// WithLevelIcons prefixes the levels of the pretty format with the icons
+func (b *LoggerBuilder) WithLevelIcons(icons map[Level]string) *LoggerBuilder {
+	b.config.LevelIcons = icons
+	return b
+}
+
+// WithCSVColumns sets the fields written by the CSV format and whether a header is written
+func (b *LoggerBuilder) WithCSVColumns(header bool, columns ...string) *LoggerBuilder {
+	b.config.CSVColumns = columns
+	b.config.CSVHeader = header
+	return b
+}
+
 // WithCaller enables or disables caller information
 func (b *LoggerBuilder) WithCaller(enabled bool) *LoggerBuilder {
 	b.config.WithCaller = enabled
@@ -47,6 +72,205 @@ func (b *LoggerBuilder) WithTimeFormat(format string) *LoggerBuilder {
 	return b
 }
 
+// WithTimestamp enables or disables the timestamp field
+func (b *LoggerBuilder) WithTimestamp(enabled bool) *LoggerBuilder {
+	b.config.DisableTimestamp = !enabled
+	return b
+}
+
+// WithTimestampFieldName sets the key used for the timestamp field
+func (b *LoggerBuilder) WithTimestampFieldName(name string) *LoggerBuilder {
+	b.config.TimestampFieldName = name
+	return b
+}
+
+// WithFieldNames sets the keys used for the level, message, caller and service fields.
+// Empty values keep the default key.
+func (b *LoggerBuilder) WithFieldNames(level, message, caller, service string) *LoggerBuilder {
+	b.config.LevelFieldName = level
+	b.config.MessageFieldName = message
+	b.config.CallerFieldName = caller
+	b.config.ServiceFieldName = service
+	return b
+}
+
+// WithDurationFormat sets how durations are rendered
+func (b *LoggerBuilder) WithDurationFormat(format string) *LoggerBuilder {
+	b.config.DurationFormat = format
+	return b
+}
+
+// WithErrorChain enables or disables expanding wrapped errors
+func (b *LoggerBuilder) WithErrorChain(enabled bool) *LoggerBuilder {
+	b.config.ErrorChain = enabled
+	return b
+}
+
+// WithStackTrace enables or disables stack traces on error, fatal and panic entries
+func (b *LoggerBuilder) WithStackTrace(enabled bool) *LoggerBuilder {
+	b.config.StackTrace = enabled
+	return b
+}
+
+// WithErrorMarshaler registers a function that adds structured fields for errors
+func (b *LoggerBuilder) WithErrorMarshaler(marshaler ErrorMarshaler) *LoggerBuilder {
+	b.config.ErrorMarshalers = append(b.config.ErrorMarshalers, marshaler)
+	return b
+}
+
+// WithRedactKeys sets field keys whose values are redacted in every entry
+func (b *LoggerBuilder) WithRedactKeys(keys ...string) *LoggerBuilder {
+	b.config.RedactKeys = append(b.config.RedactKeys, keys...)
+	return b
+}
+
+// WithScrubbers adds regex scrubbers applied to the message and string fields
+func (b *LoggerBuilder) WithScrubbers(scrubbers ...Scrubber) *LoggerBuilder {
+	b.config.Scrubbers = append(b.config.Scrubbers, scrubbers...)
+	return b
+}
+
+// WithFieldAllowlist drops every field not listed, except the standard fields
+func (b *LoggerBuilder) WithFieldAllowlist(keys ...string) *LoggerBuilder {
+	b.config.FieldAllowlist = append(b.config.FieldAllowlist, keys...)
+	return b
+}
+
+// WithFieldBlocklist drops the listed fields
+func (b *LoggerBuilder) WithFieldBlocklist(keys ...string) *LoggerBuilder {
+	b.config.FieldBlocklist = append(b.config.FieldBlocklist, keys...)
+	return b
+}
+
+// WithPseudonymization replaces the values of the given keys with an HMAC hash keyed with secret
+func (b *LoggerBuilder) WithPseudonymization(secret []byte, keys ...string) *LoggerBuilder {
+	b.config.PseudonymizeSecret = secret
+	b.config.PseudonymizeKeys = append(b.config.PseudonymizeKeys, keys...)
+	return b
+}
+
+// WithSecretDetection enables masking of likely secrets with a warning
+func (b *LoggerBuilder) WithSecretDetection(enabled bool) *LoggerBuilder {
+	b.config.DetectSecrets = enabled
+	return b
+}
+
+// WithMaxFields caps the number of fields per entry
+func (b *LoggerBuilder) WithMaxFields(max int) *LoggerBuilder {
+	b.config.MaxFields = max
+	return b
+}
+
+// WithSchema validates every entry against the schema
+func (b *LoggerBuilder) WithSchema(schema *Schema) *LoggerBuilder {
+	b.config.Schema = schema
+	return b
+}
+
+// WithClock sets the clock used for timestamps
+func (b *LoggerBuilder) WithClock(clock func() time.Time) *LoggerBuilder {
+	b.config.Clock = clock
+	return b
+}
+
+// WithDeterministic enables or disables deterministic output
+func (b *LoggerBuilder) WithDeterministic(enabled bool) *LoggerBuilder {
+	b.config.Deterministic = enabled
+	return b
+}
+
+// WithCallerFunc enables or disables the caller function field
+func (b *LoggerBuilder) WithCallerFunc(enabled bool) *LoggerBuilder {
+	b.config.CallerFunc = enabled
+	return b
+}
+
+// WithCallerTrimPrefix sets the prefix removed from caller paths
+func (b *LoggerBuilder) WithCallerTrimPrefix(prefix string) *LoggerBuilder {
+	b.config.CallerTrimPrefix = prefix
+	return b
+}
+
+// WithGoroutineID enables or disables the goroutine ID field. Debugging only,
+// as it adds noticeable overhead to every entry
+func (b *LoggerBuilder) WithGoroutineID(enabled bool) *LoggerBuilder {
+	b.config.GoroutineID = enabled
+	return b
+}
+
+// WithKeyCollision sets how WithFields handles keys already in the context
+func (b *LoggerBuilder) WithKeyCollision(policy KeyCollisionPolicy) *LoggerBuilder {
+	b.config.KeyCollision = policy
+	return b
+}
+
+// WithExitFunc sets the function called to exit after a fatal log
+func (b *LoggerBuilder) WithExitFunc(fn func(code int)) *LoggerBuilder {
+	b.config.ExitFunc = fn
+	return b
+}
+
+// WithFatalHook sets a function called before exiting after a fatal log
+func (b *LoggerBuilder) WithFatalHook(fn func()) *LoggerBuilder {
+	b.config.FatalHook = fn
+	return b
+}
+
+// WithHealthCheck adds a sink to the Health report
+func (b *LoggerBuilder) WithHealthCheck(name string, hc HealthChecker) *LoggerBuilder {
+	if b.config.HealthChecks == nil {
+		b.config.HealthChecks = make(map[string]HealthChecker)
+	}
+	b.config.HealthChecks[name] = hc
+	return b
+}
+
+// WithSigning enables the tamper-evident signature chain keyed with key
+func (b *LoggerBuilder) WithSigning(key []byte) *LoggerBuilder {
+	b.config.SigningKey = key
+	return b
+}
+
+// WithProgressInterval sets the minimum time between progress entries
+func (b *LoggerBuilder) WithProgressInterval(d time.Duration) *LoggerBuilder {
+	b.config.ProgressInterval = d
+	return b
+}
+
+// WithErrorClassifier registers a function that chooses the level of errors logged with ErrOr
+func (b *LoggerBuilder) WithErrorClassifier(classifier ErrorClassifier) *LoggerBuilder {
+	b.config.ErrorClassifiers = append(b.config.ErrorClassifiers, classifier)
+	return b
+}
+
+// WithErrorCode sets the code logged for errors matching err
+func (b *LoggerBuilder) WithErrorCode(err error, code string) *LoggerBuilder {
+	if b.config.ErrorCodes == nil {
+		b.config.ErrorCodes = make(map[error]string)
+	}
+	b.config.ErrorCodes[err] = code
+	return b
+}
+
+// WithSyslogSeverity enables or disables the numeric syslog severity field
+func (b *LoggerBuilder) WithSyslogSeverity(enabled bool) *LoggerBuilder {
+	b.config.SyslogSeverity = enabled
+	return b
+}
+
+// WithTenantOutput sets the function choosing the output of each tenant logger
+func (b *LoggerBuilder) WithTenantOutput(output func(tenant string) io.Writer) *LoggerBuilder {
+	b.config.TenantOutput = output
+	return b
+}
+
+// WithQuota caps the entries per minute for each value of the key field
+func (b *LoggerBuilder) WithQuota(perMinute int, key string) *LoggerBuilder {
+	b.config.Quota = perMinute
+	b.config.QuotaKey = key
+	return b
+}
+
 // WithServiceName sets the service name to identify logs
 func (b *LoggerBuilder) WithServiceName(name string) *LoggerBuilder {
 	b.config.ServiceName = name
@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"runtime/debug"
+)
+
+// Build information field names added by WithBuildInfo.
+const (
+	BuildVersionFieldName   = "build_version"
+	BuildRevisionFieldName  = "vcs_revision"
+	BuildModifiedFieldName  = "vcs_modified"
+	BuildGoVersionFieldName = "go_version"
+)
+
+// readBuildInfo returns the build information of the binary, replaced in tests
+var readBuildInfo = debug.ReadBuildInfo
+
+// WithBuildInfo returns a child logger adding the build of the binary to every
+// entry, as read by debug.ReadBuildInfo: the main module version, the VCS
+// revision, whether the working tree had uncommitted changes, and the Go
+// version. The VCS fields are only available for binaries built with go build
+// from a repository. The logger is returned unchanged if the build information
+// is not available.
+func (l *Logger) WithBuildInfo() *Logger {
+	info, ok := readBuildInfo()
+	if !ok {
+		return l
+	}
+	var fields []contextField
+	if info.Main.Version != "" {
+		fields = append(fields, contextField{key: BuildVersionFieldName, value: info.Main.Version})
+	}
+	for _, s := range info.Settings {
+		switch {
+		case s.Key == "vcs.revision":
+			fields = append(fields, contextField{key: BuildRevisionFieldName, value: s.Value})
+		case s.Key == "vcs.modified" && s.Value == "true":
+			fields = append(fields, contextField{key: BuildModifiedFieldName, value: true})
+		}
+	}
+	fields = append(fields, contextField{key: BuildGoVersionFieldName, value: info.GoVersion})
+	return l.withContext(mergeFields(l.fields, fields, l.keyCollision))
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"bytes"
+	"runtime/debug"
+	"testing"
+)
+
+// TestWithBuildInfo tests adding the build information to the entries
+func TestWithBuildInfo(t *testing.T) {
+	defer func(read func() (*debug.BuildInfo, bool)) { readBuildInfo = read }(readBuildInfo)
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.24.1",
+			Main:      debug.Module{Path: "github.com/acme/app", Version: "v1.4.2"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs", Value: "git"},
+				{Key: "vcs.revision", Value: "4b1ef44"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf}).WithBuildInfo()
+
+	log.Info().Msg("started")
+	assertLogContains(t, buf.String(), `"build_version":"v1.4.2"`, "info")
+	assertLogContains(t, buf.String(), `"vcs_revision":"4b1ef44"`, "")
+	assertLogContains(t, buf.String(), `"vcs_modified":true`, "")
+	assertLogContains(t, buf.String(), `"go_version":"go1.24.1"`, "")
+
+	// Without build information the logger is unchanged
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	plain := New(Config{Level: InfoLevel, Output: &buf})
+	if plain.WithBuildInfo() != plain {
+		t.Error("Expected the same logger without build information")
+	}
+}
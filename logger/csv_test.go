@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+// TestCSVFormat tests writing entries as CSV records
+func TestCSVFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{
+		Level:       InfoLevel,
+		Output:      &buf,
+		Format:      FormatCSV,
+		CSVColumns:  []string{"level", "message", "user", "attempt", "missing"},
+		CSVHeader:   true,
+		ServiceName: "api",
+	})
+
+	log.Info().Str("user", "ana, \"admin\"").Int("attempt", 2).Msg("login")
+	log.Warn().Msg("slow")
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Could not parse CSV: %v", err)
+	}
+	expected := [][]string{
+		{"level", "message", "user", "attempt", "missing"},
+		{"info", "login", "ana, \"admin\"", "2", ""},
+		{"warn", "slow", "", "", ""},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %v", len(expected), records)
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if records[i][j] != expected[i][j] {
+				t.Errorf("Record %d column %d: expected %q, got %q", i, j, expected[i][j], records[i][j])
+			}
+		}
+	}
+}
+
+// TestCSVDefaultColumns tests the default columns
+func TestCSVDefaultColumns(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf, Format: FormatCSV, ServiceName: "api"})
+
+	log.Error().Str("user", "ana").Msg("failed")
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 1 {
+		t.Fatalf("Expected a single record, got %v, %v", records, err)
+	}
+	record := records[0]
+	if len(record) != 4 || record[0] == "" || record[1] != "error" || record[2] != "api" || record[3] != "failed" {
+		t.Errorf("Expected time, level, service and message, got %q", record)
+	}
+}
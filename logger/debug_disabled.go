@@ -0,0 +1,7 @@
+//go:build easylogger_nodebug
+
+package logger
+
+// debugEnabled reports whether Trace and Debug logging is compiled in. The
+// easylogger_nodebug tag is set, so Trace and Debug calls are no-ops.
+const debugEnabled = false
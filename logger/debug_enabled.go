@@ -0,0 +1,7 @@
+//go:build !easylogger_nodebug
+
+package logger
+
+// debugEnabled reports whether Trace and Debug logging is compiled in.
+// Build with the easylogger_nodebug tag to strip it.
+const debugEnabled = true
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DeterministicTime is the timestamp of every entry in deterministic mode
+// when no Clock is configured
+var DeterministicTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// clockHook adds the timestamp read from the clock of a logger, in its
+// TimeFormat. The format is applied here rather than through zerolog's
+// TimeFieldFormat, which is shared by every logger.
+type clockHook struct {
+	clock  func() time.Time
+	format string
+}
+
+// Run implements zerolog.Hook
+func (h clockHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	t := h.clock()
+	switch h.format {
+	case "", TimeFormatUnix:
+		e.Int64(zerolog.TimestampFieldName, t.Unix())
+	case TimeFormatUnixMs:
+		e.Int64(zerolog.TimestampFieldName, t.UnixMilli())
+	default:
+		e.Str(zerolog.TimestampFieldName, t.Format(h.format))
+	}
+}
+
+// loggerClock returns the clock of a logger: Config.Clock, the fixed
+// DeterministicTime in deterministic mode, or time.Now
+func loggerClock(cfg Config) func() time.Time {
+	switch {
+	case cfg.Clock != nil:
+		return cfg.Clock
+	case cfg.Deterministic:
+		return fixedClock(DeterministicTime)
+	}
+	return time.Now
+}
+
+// formatUnixTimestamp renders the numeric timestamps of the unix formats in
+// the console default format
+func formatUnixTimestamp(format string) zerolog.Formatter {
+	return func(i any) string {
+		n, ok := i.(json.Number)
+		if !ok {
+			return fmt.Sprint(i)
+		}
+		v, err := n.Int64()
+		if err != nil {
+			return n.String()
+		}
+		t := time.Unix(v, 0)
+		if format == TimeFormatUnixMs {
+			t = time.UnixMilli(v)
+		}
+		return fmt.Sprintf("\x1b[90m%s\x1b[0m", t.Format(time.Kitchen))
+	}
+}
+
+// fixedClock returns a clock that always returns t
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// sortFields is a processor that sorts the fields of an entry by key
+func sortFields(fields []entryField) []entryField {
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].key < fields[j].key
+	})
+	return fields
+}
+
+// relativePath returns file relative to the working directory, or its base
+// name if it is outside of it
+func relativePath(file string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.Base(file)
+	}
+	rel, err := filepath.Rel(wd, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.Base(file)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDeterministicMode tests byte-stable output
+func TestDeterministicMode(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithTimeFormat(time.RFC3339),
+		WithDeterministic(true),
+	)
+	log.Info().Str("zeta", "z").Int("alpha", 1).Msg("deterministic")
+
+	line := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(line, `{"alpha":1,"caller":"deterministic_test.go:`) {
+		t.Errorf("Expected sorted keys and relative caller, got: %s", line)
+	}
+	assertLogContains(t, line, `"time":"2000-01-01T00:00:00Z","zeta":"z"}`, "info")
+
+	// The output is the same on every call
+	buf.Reset()
+	for i := 0; i < 2; i++ {
+		log.Info().Msg("same")
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[0] != lines[1] {
+		t.Errorf("Expected identical lines, got: %v", lines)
+	}
+}
+
+// TestClock tests injecting a clock for timestamps
+func TestClock(t *testing.T) {
+	var buf bytes.Buffer
+
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithTimeFormat(time.RFC3339),
+		WithClock(func() time.Time { return now }),
+	)
+	log.InfoMsg("clock")
+	assertLogContains(t, buf.String(), `"time":"2024-03-01T12:00:00Z"`, "info")
+}
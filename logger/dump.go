@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Keys used by DumpGoroutines
+const (
+	GoroutineCountFieldName = "goroutine_count"
+	GoroutinesFieldName     = "goroutines"
+)
+
+// goroutineDump is the structured snapshot of a goroutine
+type goroutineDump struct {
+	ID        uint64              `json:"id"`
+	State     string              `json:"state"`
+	Stack     []map[string]string `json:"stack"`
+	CreatedBy string              `json:"created_by,omitempty"`
+}
+
+// DumpGoroutines logs the stacks of all goroutines at warn level, to diagnose
+// deadlocks and leaks. The world is stopped while the stacks are collected, so
+// it should not be called in a hot path.
+func (l *Logger) DumpGoroutines() {
+	lb := l.Warn()
+	if lb == nil {
+		return
+	}
+	goroutines := parseGoroutines(allStacks())
+	lb.Int(GoroutineCountFieldName, len(goroutines)).
+		AddField(GoroutinesFieldName, goroutines).
+		Msg("goroutine dump")
+}
+
+// DumpGoroutinesOnSignal calls DumpGoroutines every time one of the signals
+// is received, until the returned stop function is called:
+//
+//	stop := log.DumpGoroutinesOnSignal(syscall.SIGUSR1)
+//	defer stop()
+func (l *Logger) DumpGoroutinesOnSignal(sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig...)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				l.DumpGoroutines()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// allStacks returns the stack traces of all goroutines, growing the buffer
+// until they fit
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseGoroutines parses the output of runtime.Stack. Each goroutine is a
+// header ("goroutine 7 [chan receive]:") followed by pairs of function and
+// "\tfile:line +0x1f" lines, separated by blank lines.
+func parseGoroutines(stacks []byte) []goroutineDump {
+	var out []goroutineDump
+	var g *goroutineDump
+	var fn string
+	scanner := bufio.NewScanner(bytes.NewReader(stacks))
+	scanner.Buffer(make([]byte, 0, 4096), len(stacks)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "goroutine "):
+			out = append(out, parseGoroutineHeader(line))
+			g = &out[len(out)-1]
+		case g == nil || line == "":
+		case strings.HasPrefix(line, "\t"):
+			file, lineNo := parseFileLine(line)
+			if created, ok := strings.CutPrefix(fn, "created by "); ok {
+				created, _, _ = strings.Cut(created, " in goroutine ")
+				g.CreatedBy = shortFuncName(created)
+			} else {
+				g.Stack = append(g.Stack, map[string]string{
+					"source": filepath.Base(file),
+					"line":   lineNo,
+					"func":   shortFuncName(trimArgs(fn)),
+				})
+			}
+		default:
+			fn = line
+		}
+	}
+	return out
+}
+
+// parseGoroutineHeader parses "goroutine 7 [chan receive, 2 minutes]:"
+func parseGoroutineHeader(line string) goroutineDump {
+	rest := strings.TrimPrefix(line, "goroutine ")
+	idStr, rest, _ := strings.Cut(rest, " ")
+	id, _ := strconv.ParseUint(idStr, 10, 64)
+	state := strings.TrimSuffix(strings.TrimPrefix(rest, "["), "]:")
+	return goroutineDump{ID: id, State: state}
+}
+
+// trimArgs removes the argument list from "pkg.fn(0x1, 0x2)"
+func trimArgs(fn string) string {
+	if i := strings.LastIndex(fn, "("); i > 0 && strings.HasSuffix(fn, ")") {
+		return fn[:i]
+	}
+	return fn
+}
+
+// parseFileLine parses "\t/path/file.go:42 +0x1f" into the file and line
+func parseFileLine(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if i := strings.LastIndex(line, " +0x"); i >= 0 {
+		line = line[:i]
+	}
+	i := strings.LastIndex(line, ":")
+	if i < 0 {
+		return line, ""
+	}
+	return line[:i], line[i+1:]
+}
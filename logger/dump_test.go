@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestDumpGoroutines tests logging a structured snapshot of all goroutines
+func TestDumpGoroutines(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false))
+
+	block := make(chan struct{})
+	defer close(block)
+	go func() { <-block }()
+
+	log.DumpGoroutines()
+
+	var logData struct {
+		Level      string          `json:"level"`
+		Count      int             `json:"goroutine_count"`
+		Goroutines []goroutineDump `json:"goroutines"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if logData.Level != "warn" || logData.Count < 2 || logData.Count != len(logData.Goroutines) {
+		t.Fatalf("Unexpected dump: %s", buf.String())
+	}
+
+	var blocked bool
+	for _, g := range logData.Goroutines {
+		if g.ID == 0 || g.State == "" || len(g.Stack) == 0 {
+			t.Errorf("Incomplete goroutine: %+v", g)
+		}
+		if g.CreatedBy == "TestDumpGoroutines" {
+			blocked = true
+		}
+	}
+	if !blocked {
+		t.Errorf("Expected the blocked goroutine in the dump, got: %s", buf.String())
+	}
+}
+
+// TestParseGoroutines tests parsing runtime.Stack output
+func TestParseGoroutines(t *testing.T) {
+	stacks := []byte(`goroutine 7 [chan receive, 2 minutes]:
+main.worker(0xc000012345)
+	/src/app/worker.go:42 +0x1f
+created by main.main in goroutine 1
+	/src/app/main.go:10 +0x45
+
+goroutine 1 [running]:
+main.main()
+	/src/app/main.go:12 +0x1d
+`)
+	got := parseGoroutines(stacks)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 goroutines, got %+v", got)
+	}
+	g := got[0]
+	if g.ID != 7 || g.State != "chan receive, 2 minutes" || g.CreatedBy != "main" {
+		t.Errorf("Unexpected goroutine: %+v", g)
+	}
+	if len(g.Stack) != 1 || g.Stack[0]["func"] != "worker" || g.Stack[0]["source"] != "worker.go" || g.Stack[0]["line"] != "42" {
+		t.Errorf("Unexpected stack: %+v", g.Stack)
+	}
+	if got[1].ID != 1 || got[1].Stack[0]["func"] != "main" {
+		t.Errorf("Unexpected goroutine: %+v", got[1])
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// EventFieldName is the key holding an event emitted by Emit that does not
+// encode to a JSON object
+const EventFieldName = "event"
+
+// EventNamer is implemented by events choosing the message written by Emit
+type EventNamer interface {
+	EventName() string
+}
+
+// Emit writes a domain event as a structured entry at the given level. The
+// event is encoded with encoding/json, so the exported fields of a struct
+// become the fields of the entry, honoring their json tags:
+//
+//	type UserSignedUp struct {
+//		UserID string `json:"user_id"`
+//		Plan   string `json:"plan,omitempty"`
+//	}
+//
+//	logger.Emit(log, logger.InfoLevel, UserSignedUp{UserID: "u1", Plan: "pro"})
+//
+// The message is the event's EventName if it implements EventNamer, or its
+// type name. Events that do not encode to an object, such as strings, are
+// written in the "event" field, and encoding errors in the error field.
+func Emit[T any](l *Logger, level Level, event T) {
+	lb := l.WithLevel(level)
+	if !lb.Enabled() {
+		return
+	}
+	data, err := json.Marshal(event)
+	switch {
+	case err != nil:
+		lb.WithError(err)
+	case isObject(data):
+		fields, err := parseEntry(data)
+		if err != nil {
+			lb.WithError(err)
+		}
+		for _, f := range fields {
+			lb.RawJSON(f.key, f.value)
+		}
+	default:
+		lb.RawJSON(EventFieldName, data)
+	}
+	lb.Msg(eventName(event))
+}
+
+// eventName returns the message of an event emitted by Emit
+func eventName(event any) string {
+	if n, ok := event.(EventNamer); ok {
+		return n.EventName()
+	}
+	t := reflect.TypeOf(event)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name()
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// userSignedUp is a domain event emitted in tests
+type userSignedUp struct {
+	UserID  string `json:"user_id"`
+	Plan    string `json:"plan,omitempty"`
+	Seats   int
+	Secret  string `json:"-"`
+	private string
+}
+
+// renamedEvent is an event choosing its message
+type renamedEvent struct {
+	ID int `json:"id"`
+}
+
+// EventName implements EventNamer
+func (renamedEvent) EventName() string {
+	return "renamed"
+}
+
+// TestEmit tests emitting structs as structured entries
+func TestEmit(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf})
+
+	Emit(log, WarnLevel, userSignedUp{UserID: "u1", Seats: 3, Secret: "s", private: "p"})
+	assertLogContains(t, buf.String(), `"user_id":"u1"`, "warn")
+	assertLogContains(t, buf.String(), `"Seats":3`, "")
+	assertLogContains(t, buf.String(), `"message":"userSignedUp"`, "")
+	assertLogNotContains(t, buf.String(), "plan")
+	assertLogNotContains(t, buf.String(), "Secret")
+	assertLogNotContains(t, buf.String(), "private")
+
+	buf.Reset()
+	Emit(log, InfoLevel, &renamedEvent{ID: 7})
+	assertLogContains(t, buf.String(), `"id":7`, "info")
+	assertLogContains(t, buf.String(), `"message":"renamed"`, "")
+
+	buf.Reset()
+	Emit(log, InfoLevel, "cache warmed")
+	assertLogContains(t, buf.String(), `"event":"cache warmed"`, "info")
+
+	buf.Reset()
+	Emit(log, DebugLevel, userSignedUp{UserID: "u2"})
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output below the level, got %s", buf.String())
+	}
+}
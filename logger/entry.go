@@ -0,0 +1,207 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// errNotObject is returned when a log entry is not a JSON object
+var errNotObject = errors.New("log entry is not a JSON object")
+
+// entryField is a top-level key/value pair of a serialized log entry
+type entryField struct {
+	key   string
+	value json.RawMessage
+}
+
+// entryProcessor transforms the fields of an entry before it is written
+type entryProcessor func(fields []entryField) []entryField
+
+// entryProcessors returns the processors enabled by the configuration. meta
+// writes entries generated by the processors themselves, such as warnings.
+func entryProcessors(cfg Config, meta zerolog.Logger) []entryProcessor {
+	var processors []entryProcessor
+	if cfg.Quota > 0 {
+		processors = append(processors, enforceQuota(cfg, meta))
+	}
+	if len(cfg.FieldAllowlist) > 0 {
+		processors = append(processors, allowFields(cfg.FieldAllowlist, cfg))
+	}
+	if len(cfg.FieldBlocklist) > 0 {
+		processors = append(processors, blockFields(cfg.FieldBlocklist))
+	}
+	if cfg.MaxFields > 0 {
+		processors = append(processors, capFields(cfg.MaxFields, cfg))
+	}
+	if len(cfg.RedactKeys) > 0 {
+		processors = append(processors, redactKeys(cfg.RedactKeys))
+	}
+	if len(cfg.PseudonymizeKeys) > 0 {
+		processors = append(processors, pseudonymizeKeys(cfg.PseudonymizeKeys, cfg.PseudonymizeSecret))
+	}
+	if len(cfg.Scrubbers) > 0 {
+		processors = append(processors, scrubValues(cfg.Scrubbers))
+	}
+	if cfg.Schema != nil {
+		processors = append(processors, validateSchema(cfg.Schema))
+	}
+	if cfg.DetectSecrets {
+		processors = append(processors, detectSecrets(meta, fieldName(cfg.CallerFieldName, DefaultCallerFieldName)))
+	}
+	if cfg.Deterministic {
+		processors = append(processors, sortFields)
+	}
+	return processors
+}
+
+// renameFields returns a processor renaming the keys found in names
+func renameFields(names map[string]string) entryProcessor {
+	return func(fields []entryField) []entryField {
+		for i, f := range fields {
+			if name, ok := names[f.key]; ok {
+				fields[i].key = name
+			}
+		}
+		return fields
+	}
+}
+
+// processWriter decodes each JSON entry written by zerolog, applies the
+// processors in order and writes the re-encoded entry to out. Field order is
+// preserved. Entries that are not valid JSON objects are replaced with an
+// error entry, so they never bypass the processors, and entries for which a
+// processor returns nil are dropped.
+type processWriter struct {
+	out        io.Writer
+	processors []entryProcessor
+	captures   captures
+}
+
+// newProcessWriter wraps out with the given processors
+func newProcessWriter(out io.Writer, processors []entryProcessor) io.Writer {
+	if len(processors) == 0 {
+		return out
+	}
+	return &processWriter{out: out, processors: processors}
+}
+
+// Write implements io.Writer
+func (w *processWriter) Write(p []byte) (int, error) {
+	if len(w.processors) == 0 && w.captures.count.Load() == 0 {
+		return w.out.Write(p)
+	}
+	fields, err := parseEntry(p)
+	if err != nil {
+		fields = malformedEntry(err)
+	}
+	var capture *entryCapture
+	if err == nil && w.captures.count.Load() > 0 {
+		if fields, capture = w.takeMarked(fields); capture != nil {
+			capture.written = true
+		}
+	}
+	for _, process := range w.processors {
+		if fields = process(fields); fields == nil {
+			return len(p), nil
+		}
+	}
+	entry := encodeEntry(fields)
+	if capture != nil {
+		capture.entry = entry
+	}
+	if _, err := w.out.Write(append(entry, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// MalformedEntryMessage is the message of the entry replacing an entry that is not valid JSON
+const MalformedEntryMessage = "malformed log entry dropped"
+
+// malformedEntry returns the fields of the error entry replacing an entry that
+// could not be parsed. The entry itself is not included, as its fields could
+// not be redacted.
+func malformedEntry(err error) []entryField {
+	return []entryField{
+		{key: zerolog.LevelFieldName, value: mustMarshal(zerolog.LevelErrorValue)},
+		{key: zerolog.ErrorFieldName, value: mustMarshal(err.Error())},
+		{key: zerolog.MessageFieldName, value: mustMarshal(MalformedEntryMessage)},
+	}
+}
+
+// parseEntry decodes a JSON object into its top-level fields, keeping their order
+func parseEntry(data []byte) ([]entryField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errNotObject
+	}
+	fields := make([]entryField, 0, 8)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, entryField{key: key, value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// encodeEntry encodes the fields as a JSON object
+func encodeEntry(fields []entryField) []byte {
+	buf := make([]byte, 0, 256)
+	buf = append(buf, '{')
+	for i, f := range fields {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		key, _ := json.Marshal(f.key)
+		buf = append(buf, key...)
+		buf = append(buf, ':')
+		buf = append(buf, f.value...)
+	}
+	return append(buf, '}')
+}
+
+// isObject reports whether the raw value is a JSON object
+func isObject(value json.RawMessage) bool {
+	return len(value) > 0 && value[0] == '{'
+}
+
+// isArray reports whether the raw value is a JSON array
+func isArray(value json.RawMessage) bool {
+	return len(value) > 0 && value[0] == '['
+}
+
+// stringValue returns the raw value as a string if it is a JSON string
+func stringValue(value json.RawMessage) (string, bool) {
+	if len(value) == 0 || value[0] != '"' {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// mustMarshal encodes v as a raw JSON value
+func mustMarshal(v any) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(err.Error())
+	}
+	return b
+}
@@ -12,6 +12,10 @@ const (
 	EnvLogFormat = "LOG_FORMAT"
 	// EnvLogCaller is the environment variable to configure if caller info is included
 	EnvLogCaller = "LOG_CALLER"
+	// EnvLogTimeFormat is the environment variable to configure the timestamp format
+	EnvLogTimeFormat = "LOG_TIME_FORMAT"
+	// EnvLogPalette is the environment variable to configure the pretty level colors
+	EnvLogPalette = "LOG_PALETTE"
 	// EnvServiceName is the environment variable for service name
 	EnvServiceName = "SERVICE_NAME"
 )
@@ -39,7 +43,9 @@ func NewFromEnv() *Logger {
 	logLevel := GetEnvStr(EnvLogLevel, "info")
 	logFormat := GetEnvStr(EnvLogFormat, "json")
 	logCallerEnabled := GetEnvBool(EnvLogCaller, true)
+	timeFormat := GetEnvStr(EnvLogTimeFormat, "2006-01-02T15:04:05.000Z07:00") // RFC3339 with milliseconds
 	serviceName := GetEnvStr(EnvServiceName, "")
+	logPalette := GetEnvStr(EnvLogPalette, "")
 
 	// Determine log level
 	level, err := ParseLevel(logLevel)
@@ -47,12 +53,26 @@ func NewFromEnv() *Logger {
 		level = InfoLevel
 	}
 
+	// Determine log format
+	format, err := ParseFormat(logFormat)
+	if err != nil {
+		format = FormatJSON
+	}
+
+	// Determine pretty palette
+	palette, err := ParsePalette(logPalette)
+	if err != nil {
+		palette = PaletteDefault
+	}
+
 	// Create configuration
 	cfg := Config{
 		Level:       level,
-		Pretty:      logFormat == "pretty" || logFormat == "console",
+		Pretty:      format == FormatPretty,
+		Format:      format,
+		Palette:     palette,
 		WithCaller:  logCallerEnabled,
-		TimeFormat:  "2006-01-02T15:04:05.000Z07:00", // RFC3339 with milliseconds
+		TimeFormat:  timeFormat,
 		ServiceName: serviceName,
 	}
 
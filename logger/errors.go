@@ -0,0 +1,139 @@
+package logger
+
+import "errors"
+
+// ErrorMarshaler adds structured fields describing err to the log builder.
+// It is called for every non-nil error passed to WithError, so implementations
+// should check the error type and return without adding fields when it does
+// not apply:
+//
+//	func(lb *LogBuilder, err error) {
+//		var apiErr *APIError
+//		if errors.As(err, &apiErr) {
+//			lb.Str("code", apiErr.Code).Bool("retryable", apiErr.Retryable)
+//		}
+//	}
+type ErrorMarshaler func(lb *LogBuilder, err error)
+
+// Coder is implemented by errors carrying an application error code, which
+// WithError adds in the "error_code" field
+type Coder interface {
+	Code() string
+}
+
+// ErrorCode returns the code of err: the code of the first error in its chain
+// implementing Coder, or else the code registered in Config.ErrorCodes for an
+// error matching it. If several registered errors match, any of their codes
+// may be returned. It returns "" if err has no code.
+func (l *Logger) ErrorCode(err error) string {
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+	for target, code := range l.cfg.ErrorCodes {
+		if errors.Is(err, target) {
+			return code
+		}
+	}
+	return ""
+}
+
+// CheckErr logs err at error level with the given message if err is not nil.
+// It returns true if an error was logged:
+//
+//	if log.CheckErr(f.Close(), "closing file") {
+//		return
+//	}
+func (l *Logger) CheckErr(err error, msg string, values ...any) bool {
+	if err == nil {
+		return false
+	}
+	l.Error().WithError(err).Msg(msg, values...)
+	return true
+}
+
+// ErrIf returns an error level log builder with err attached if err is not nil,
+// or a no-op builder otherwise:
+//
+//	log.ErrIf(err).Str("file", name).Msg("closing file")
+func (l *Logger) ErrIf(err error) *LogBuilder {
+	if err == nil {
+		return l.newLogBuilder(nil)
+	}
+	return l.Error().WithError(err)
+}
+
+// ErrorClassifier returns the level to log err at, and false if it does not
+// recognize err. Classifiers are registered with Config.ErrorClassifiers.
+type ErrorClassifier func(err error) (Level, bool)
+
+// ErrorIs classifies the errors matching target with errors.Is at level:
+//
+//	logger.ErrorIs(context.Canceled, logger.DebugLevel)
+func ErrorIs(target error, level Level) ErrorClassifier {
+	return func(err error) (Level, bool) {
+		return level, errors.Is(err, target)
+	}
+}
+
+// ErrorAs classifies the errors matching the type T with errors.As at level:
+//
+//	logger.ErrorAs[*ValidationError](logger.WarnLevel)
+func ErrorAs[T error](level Level) ErrorClassifier {
+	return func(err error) (Level, bool) {
+		var target T
+		return level, errors.As(err, &target)
+	}
+}
+
+// ErrorLevelFor returns the level of the first classifier recognizing err, or
+// ErrorLevel if none does
+func (l *Logger) ErrorLevelFor(err error) Level {
+	for _, classify := range l.cfg.ErrorClassifiers {
+		if level, ok := classify(err); ok {
+			return level
+		}
+	}
+	return ErrorLevel
+}
+
+// ErrOr returns a log builder with err attached at the level chosen by
+// ErrorLevelFor if err is not nil, or at the fallback level otherwise:
+//
+//	log.ErrOr(err, logger.InfoLevel).Str("job", name).Msg("job finished")
+//
+// Fatal and panic levels are logged at error level, so the classification of
+// an error never ends the process.
+func (l *Logger) ErrOr(err error, fallback Level) *LogBuilder {
+	if err == nil {
+		return l.logAt(fallback)
+	}
+	return l.logAt(l.ErrorLevelFor(err)).WithError(err)
+}
+
+// ErrOrWarn is ErrOr with a warn level fallback
+func (l *Logger) ErrOrWarn(err error) *LogBuilder {
+	return l.ErrOr(err, WarnLevel)
+}
+
+// logAt creates a log at level, logging fatal, panic and unknown levels at error level
+func (l *Logger) logAt(level Level) *LogBuilder {
+	switch level {
+	case TraceLevel:
+		return l.Trace()
+	case DebugLevel:
+		return l.Debug()
+	case InfoLevel:
+		return l.Info()
+	case WarnLevel:
+		return l.Warn()
+	case ErrorLevel, FatalLevel, PanicLevel:
+		return l.Error()
+	case NoLevel:
+		return l.Log()
+	}
+	if _, ok := lookupLevel(level); ok {
+		return l.customLog(level)
+	}
+	return l.Error()
+}
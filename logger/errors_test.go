@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// apiError is a domain error used to test error marshalers
+type apiError struct {
+	Code      string
+	Retryable bool
+}
+
+func (e *apiError) Error() string {
+	return "api error " + e.Code
+}
+
+// TestErrorMarshaler tests registering an error marshaler
+func TestErrorMarshaler(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithErrorMarshaler(func(lb *LogBuilder, err error) {
+			var apiErr *apiError
+			if errors.As(err, &apiErr) {
+				lb.Str("code", apiErr.Code).Bool("retryable", apiErr.Retryable)
+			}
+		}),
+	)
+
+	err := fmt.Errorf("calling upstream: %w", &apiError{Code: "E42", Retryable: true})
+	log.Error().WithError(err).Msg("upstream failed")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if logData["code"] != "E42" || logData["retryable"] != true {
+		t.Errorf("Expected marshaled error fields, got: %s", buf.String())
+	}
+
+	// Other errors are left untouched
+	buf.Reset()
+	log.Error().WithError(errors.New("plain")).Msg("plain failure")
+	assertLogNotContains(t, buf.String(), `"code"`)
+}
+
+// TestCheckErr tests the error convenience helpers
+func TestCheckErr(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	if log.CheckErr(nil, "closing file") {
+		t.Error("CheckErr should return false for a nil error")
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Nothing should be logged for a nil error, got: %s", buf.String())
+	}
+
+	if !log.CheckErr(errors.New("disk full"), "closing file %s", "a.txt") {
+		t.Error("CheckErr should return true for a non-nil error")
+	}
+	assertLogContains(t, buf.String(), "closing file a.txt", "error")
+	assertLogContains(t, buf.String(), "disk full", "")
+
+	buf.Reset()
+	log.ErrIf(nil).Str("file", "a.txt").Msg("closing file")
+	if buf.Len() > 0 {
+		t.Errorf("Nothing should be logged for a nil error, got: %s", buf.String())
+	}
+
+	log.ErrIf(errors.New("disk full")).Str("file", "a.txt").Msg("closing file")
+	assertLogContains(t, buf.String(), "a.txt", "error")
+}
+
+// TestErrOr tests the level selection of ErrOr
+func TestErrOr(t *testing.T) {
+	skipWithoutDebug(t)
+
+	var buf bytes.Buffer
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithLevel(DebugLevel),
+		WithErrorClassifier(ErrorIs(context.Canceled, DebugLevel)),
+		WithErrorClassifier(ErrorAs[*apiError](WarnLevel)),
+	)
+
+	tests := []struct {
+		name  string
+		err   error
+		level string
+	}{
+		{"nil error", nil, "info"},
+		{"unclassified", errors.New("boom"), "error"},
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), "debug"},
+		{"typed", fmt.Errorf("call: %w", &apiError{Code: "E1"}), "warn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			log.ErrOr(tt.err, InfoLevel).Msg("job finished")
+			assertLogContains(t, buf.String(), "job finished", tt.level)
+			if tt.err != nil {
+				assertLogContains(t, buf.String(), tt.err.Error(), tt.level)
+			}
+		})
+	}
+
+	buf.Reset()
+	log.ErrOrWarn(nil).Msg("retrying")
+	assertLogContains(t, buf.String(), "retrying", "warn")
+
+	// A fatal classification is logged at error level
+	fatal := NewWithOptions(WithOutput(&buf), WithErrorClassifier(ErrorIs(io.EOF, FatalLevel)))
+	buf.Reset()
+	fatal.ErrOr(io.EOF, InfoLevel).Msg("read failed")
+	assertLogContains(t, buf.String(), "read failed", "error")
+}
+
+// codedError is an error implementing Coder
+type codedError struct {
+	code string
+}
+
+func (e *codedError) Error() string { return "coded error" }
+func (e *codedError) Code() string  { return e.code }
+
+// TestErrorCode tests the error_code field added by WithError
+func TestErrorCode(t *testing.T) {
+	var buf bytes.Buffer
+	errNotFound := errors.New("not found")
+	log := NewWithOptions(WithOutput(&buf), WithErrorCode(errNotFound, "NOT_FOUND"))
+
+	log.Error().WithError(fmt.Errorf("load user: %w", &codedError{code: "USER_LOCKED"})).Msg("login failed")
+	assertLogContains(t, buf.String(), `"error_code":"USER_LOCKED"`, "error")
+
+	buf.Reset()
+	log.Error().WithError(fmt.Errorf("load user: %w", errNotFound)).Msg("login failed")
+	assertLogContains(t, buf.String(), `"error_code":"NOT_FOUND"`, "error")
+
+	buf.Reset()
+	log.Error().WithError(errors.New("boom")).Msg("login failed")
+	assertLogNotContains(t, buf.String(), "error_code")
+
+	if code := log.ErrorCode(nil); code != "" {
+		t.Errorf("Expected no code for a nil error, got %q", code)
+	}
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// DefaultShutdownTimeout bounds the close of the logger by CloseOnSignal
+const DefaultShutdownTimeout = 5 * time.Second
+
+// Flusher is implemented by buffered or asynchronous outputs, such as Batcher
+// and bufio.Writer, that hold entries before writing them
+type Flusher interface {
+	Flush() error
+}
+
+// Flush writes the entries held by the output if it implements Flusher. It is
+// called before exiting on Fatal and before panicking on Panic, so the last
+// entry is never lost.
+func (l *Logger) Flush() error {
+	if f, ok := l.cfg.Output.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close flushes the output and closes it if it implements io.Closer, unless it
+// is the standard output or error. It is meant for the end of the program:
+// entries logged afterwards may be lost.
+func (l *Logger) Close() error {
+	err := l.Flush()
+	if closer, ok := l.cfg.Output.(io.Closer); ok && closer != os.Stdout && closer != os.Stderr {
+		err = errors.Join(err, closer.Close())
+	}
+	return err
+}
+
+// Shutdown closes the logger like Close, but gives up when ctx is done, so a
+// slow sink cannot hold the end of the program past a deadline. It then
+// returns ctx.Err() and the close goes on in the background.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() { done <- l.Close() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CloseOnSignal handles the signals by logging them, closing l with Shutdown
+// within DefaultShutdownTimeout and exiting with Config.ExitFunc, so container
+// restarts never truncate the last entries. The exit code is 128 plus the
+// signal number, as shells report it. The handler is removed by the returned
+// stop function:
+//
+//	stop := logger.CloseOnSignal(log, os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//
+// Programs with their own graceful shutdown should call Shutdown at its end instead.
+func CloseOnSignal(l *Logger, sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig...)
+	go func() {
+		select {
+		case s := <-ch:
+			l.Info().Str("signal", s.String()).Msg("signal received, shutting down")
+			ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+			l.Shutdown(ctx)
+			cancel()
+			code := 1
+			if n, ok := s.(syscall.Signal); ok {
+				code = 128 + int(n)
+			}
+			exitFunc := l.cfg.ExitFunc
+			if exitFunc == nil {
+				exitFunc = os.Exit
+			}
+			exitFunc(code)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// closeOnDone closes c once ctx is done, passing the error to onError if it is not nil
+func closeOnDone(ctx context.Context, c io.Closer, onError func(error)) {
+	context.AfterFunc(ctx, func() {
+		if err := c.Close(); err != nil && onError != nil {
+			onError(err)
+		}
+	})
+}
+
+// exit flushes the output, runs the fatal hook and terminates the process.
+// The output is closed, like zerolog does, only before os.Exit: a custom
+// ExitFunc may return, and the logger must stay usable afterwards.
+func (l *Logger) exit(code int) {
+	l.Flush()
+	if l.cfg.FatalHook != nil {
+		l.cfg.FatalHook()
+	}
+	if l.cfg.ExitFunc != nil {
+		l.cfg.ExitFunc(code)
+		return
+	}
+	l.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFatalExitFunc tests intercepting the exit after a fatal log
+func TestFatalExitFunc(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []string
+	exitCode := -1
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithFatalHook(func() { calls = append(calls, "hook") }),
+		WithExitFunc(func(code int) {
+			calls = append(calls, "exit")
+			exitCode = code
+		}),
+	)
+
+	log.Fatal().Str("component", "db").Msg("cannot connect")
+	assertLogContains(t, buf.String(), "cannot connect", "fatal")
+	if exitCode != 1 || len(calls) != 2 || calls[0] != "hook" || calls[1] != "exit" {
+		t.Errorf("Expected the hook then exit(1), got %v with code %d", calls, exitCode)
+	}
+
+	buf.Reset()
+	calls = nil
+	log.FatalMsg("fatal %s", "message")
+	assertLogContains(t, buf.String(), "fatal message", "fatal")
+	if len(calls) != 2 {
+		t.Errorf("Expected FatalMsg to exit, got %v", calls)
+	}
+
+	// A discarded fatal log does not exit
+	calls = nil
+	log.Fatal().When(false).Msg("skipped")
+	if len(calls) != 0 {
+		t.Errorf("Expected no exit for a discarded log, got %v", calls)
+	}
+}
+
+// TestFlushOnFatalAndPanic tests that buffered outputs are flushed before exiting or panicking
+func TestFlushOnFatalAndPanic(t *testing.T) {
+	var out bytes.Buffer
+	buffered := bufio.NewWriter(&out)
+
+	var flushed bool
+	log := NewWithOptions(
+		WithOutput(buffered),
+		WithCaller(false),
+		WithExitFunc(func(int) { flushed = out.Len() > 0 }),
+	)
+
+	log.Info().Msg("buffered")
+	if out.Len() > 0 {
+		t.Fatal("Expected the entry to be buffered")
+	}
+
+	log.FatalMsg("fatal message")
+	if !flushed {
+		t.Error("Expected the output to be flushed before exiting")
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assertLogContains(t, lines[len(lines)-1], "fatal message", "fatal")
+
+	out.Reset()
+	func() {
+		defer func() {
+			r := recover()
+			if pe, ok := r.(*PanicError); !ok || pe.Message != "panic message" {
+				t.Errorf("Expected panic with the message, got %v", r)
+			}
+		}()
+		log.Panic().Str("key", "value").Msg("panic message")
+	}()
+	assertLogContains(t, out.String(), "panic message", "panic")
+}
+
+// closeRecorder is an output recording whether it was closed
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+// TestFatalExitFuncReturns tests that the output stays open when ExitFunc returns
+func TestFatalExitFuncReturns(t *testing.T) {
+	out := &closeRecorder{}
+	log := NewWithOptions(WithOutput(out), WithCaller(false), WithExitFunc(func(int) {}))
+
+	log.FatalMsg("fatal message")
+	log.InfoMsg("after exit")
+	if out.closed {
+		t.Error("Expected the output not to be closed before a custom ExitFunc")
+	}
+	assertLogContains(t, out.String(), "after exit", "")
+}
+
+// TestFatalExitCode tests setting the exit code per Fatal log
+func TestFatalExitCode(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := -1
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithExitFunc(func(code int) { exitCode = code }),
+	)
+
+	log.Fatal().ExitCode(2).Str("config", "app.yaml").Msg("invalid configuration")
+	assertLogContains(t, buf.String(), "invalid configuration", "fatal")
+	if exitCode != 2 {
+		t.Errorf("Expected exit code 2, got %d", exitCode)
+	}
+
+	// The code is ignored on other levels
+	buf.Reset()
+	exitCode = -1
+	log.Error().ExitCode(3).Msg("not fatal")
+	if exitCode != -1 {
+		t.Errorf("Expected no exit, got code %d", exitCode)
+	}
+}
+
+// slowCloser is an output whose Close blocks until released
+type slowCloser struct {
+	bytes.Buffer
+	release chan struct{}
+}
+
+func (c *slowCloser) Close() error {
+	<-c.release
+	return nil
+}
+
+// TestShutdown tests bounding the final close by the context deadline
+func TestShutdown(t *testing.T) {
+	out := &slowCloser{release: make(chan struct{})}
+	log := NewWithOptions(WithOutput(out))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := log.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+
+	close(out.release)
+	if err := log.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown failed: %v", err)
+	}
+}
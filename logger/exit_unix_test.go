@@ -0,0 +1,45 @@
+//go:build unix
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestCloseOnSignal tests closing the logger and exiting on a signal
+func TestCloseOnSignal(t *testing.T) {
+	file, err := OpenFile(filepath.Join(t.TempDir(), "app.log"))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	exitCode := make(chan int, 1)
+	log := NewWithOptions(WithOutput(file), WithExitFunc(func(code int) { exitCode <- code }))
+
+	stop := CloseOnSignal(log, syscall.SIGUSR2)
+	defer stop()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case code := <-exitCode:
+		if code != 128+int(syscall.SIGUSR2) {
+			t.Errorf("Expected exit code %d, got %d", 128+int(syscall.SIGUSR2), code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the process to exit on the signal")
+	}
+	if _, err := file.Write([]byte("{}\n")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected the output to be closed, got %v", err)
+	}
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLogContains(t, string(data), `"signal":"user defined signal 2"`, "info")
+}
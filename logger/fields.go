@@ -0,0 +1,242 @@
+package logger
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// KeyCollisionPolicy decides what happens when a context field is added with a
+// key the logger context already has
+type KeyCollisionPolicy int
+
+const (
+	// KeyCollisionOverwrite replaces the existing value, keeping the field's
+	// original position (default)
+	KeyCollisionOverwrite KeyCollisionPolicy = iota
+	// KeyCollisionKeepFirst keeps the existing value and ignores the new one
+	KeyCollisionKeepFirst
+	// KeyCollisionSuffix keeps both values, adding the new one under the key
+	// with a numeric suffix, such as "user_2"
+	KeyCollisionSuffix
+)
+
+// keyCollisionNames are the text encodings of the collision policies
+var keyCollisionNames = []string{"overwrite", "keep_first", "suffix"}
+
+// String returns the name of the policy, such as "keep_first"
+func (p KeyCollisionPolicy) String() string {
+	if p < 0 || int(p) >= len(keyCollisionNames) {
+		return "unknown"
+	}
+	return keyCollisionNames[p]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (p KeyCollisionPolicy) MarshalText() ([]byte, error) {
+	if p < 0 || int(p) >= len(keyCollisionNames) {
+		return nil, fmt.Errorf("invalid key collision policy: %d", p)
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the policy names
+func (p *KeyCollisionPolicy) UnmarshalText(text []byte) error {
+	i := slices.Index(keyCollisionNames, strings.ToLower(string(text)))
+	if i < 0 {
+		return fmt.Errorf("invalid key collision policy: %s", text)
+	}
+	*p = KeyCollisionPolicy(i)
+	return nil
+}
+
+// contextField is a field of the logger context
+type contextField struct {
+	key   string
+	value any
+}
+
+// mergeFields returns the context fields with the new fields added following
+// the collision policy. The context fields are not modified.
+func mergeFields(fields []contextField, add []contextField, policy KeyCollisionPolicy) []contextField {
+	merged := make([]contextField, len(fields), len(fields)+len(add))
+	copy(merged, fields)
+	for _, f := range add {
+		i := fieldIndex(merged, f.key)
+		switch {
+		case i < 0:
+			merged = append(merged, f)
+		case policy == KeyCollisionKeepFirst:
+		case policy == KeyCollisionSuffix:
+			f.key = suffixedKey(merged, f.key)
+			merged = append(merged, f)
+		default:
+			merged[i].value = f.value
+		}
+	}
+	return merged
+}
+
+// removeFields returns the context fields without the given keys. The context
+// fields are not modified.
+func removeFields(fields []contextField, keys []string) []contextField {
+	out := make([]contextField, 0, len(fields))
+	for _, f := range fields {
+		if !slices.Contains(keys, f.key) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// fieldIndex returns the index of the field with the key, or -1
+func fieldIndex(fields []contextField, key string) int {
+	for i, f := range fields {
+		if f.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// suffixedKey returns the first of key_2, key_3... not used by the fields
+func suffixedKey(fields []contextField, key string) string {
+	for n := 2; ; n++ {
+		candidate := key + "_" + strconv.Itoa(n)
+		if fieldIndex(fields, candidate) < 0 {
+			return candidate
+		}
+	}
+}
+
+// sortedFields returns the map entries as context fields with prefixed keys,
+// sorted so that the output order does not depend on map iteration
+func sortedFields(fields map[string]any, prefix string) []contextField {
+	out := make([]contextField, 0, len(fields))
+	for k, v := range fields {
+		out = append(out, contextField{key: prefix + k, value: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+// withContext returns a copy of the logger with its context replaced by the
+// fields. The zerolog context is rebuilt from the base logger, so every key
+// is written once.
+func (l *Logger) withContext(fields []contextField) *Logger {
+	ctx := l.base.With()
+	for _, f := range fields {
+		if s, ok := f.value.(string); ok {
+			ctx = ctx.Str(f.key, s)
+			continue
+		}
+		ctx = ctx.Interface(f.key, marshalValue(f.value))
+	}
+	child := *l
+	child.fields = fields
+	child.zl = ctx.Logger()
+	return &child
+}
+
+// setBase replaces the base logger, rebuilding the context on top of it
+func (l *Logger) setBase(base zerolog.Logger) {
+	l.base = base
+	l.zl = l.withContext(l.fields).zl
+}
+
+// ContextBuilder adds typed fields to the context of a child logger. It is
+// created with Logger.With and finished with Logger:
+//
+//	reqLog := log.With().Str("request_id", id).Int("attempt", 1).Logger()
+//
+// Keys get the logger prefix and follow its KeyCollision policy, as with WithFields.
+type ContextBuilder struct {
+	logger *Logger
+	fields []contextField
+}
+
+// Logger returns a new logger with the fields added to the context
+func (cb *ContextBuilder) Logger() *Logger {
+	return cb.logger.withContext(mergeFields(cb.logger.fields, cb.fields, cb.logger.keyCollision))
+}
+
+// add appends a field with the prefixed key
+func (cb *ContextBuilder) add(key string, value any) *ContextBuilder {
+	cb.fields = append(cb.fields, contextField{key: cb.logger.prefix + key, value: value})
+	return cb
+}
+
+// Str adds a string field to the context
+func (cb *ContextBuilder) Str(key, value string) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Strs adds a string slice field to the context
+func (cb *ContextBuilder) Strs(key string, values []string) *ContextBuilder {
+	return cb.add(key, values)
+}
+
+// Int adds an integer field to the context
+func (cb *ContextBuilder) Int(key string, value int) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Int64 adds an int64 field to the context
+func (cb *ContextBuilder) Int64(key string, value int64) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Uint64 adds a uint64 field to the context
+func (cb *ContextBuilder) Uint64(key string, value uint64) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Float64 adds a float64 field to the context
+func (cb *ContextBuilder) Float64(key string, value float64) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Bool adds a boolean field to the context
+func (cb *ContextBuilder) Bool(key string, value bool) *ContextBuilder {
+	return cb.add(key, value)
+}
+
+// Dur adds a duration field to the context, rendered according to the configured DurationFormat
+func (cb *ContextBuilder) Dur(key string, d time.Duration) *ContextBuilder {
+	switch cb.logger.durationFormat {
+	case DurationFormatSeconds:
+		return cb.add(key, d.Seconds())
+	case DurationFormatString:
+		return cb.add(key, d.String())
+	default:
+		return cb.add(key, float64(d)/float64(time.Millisecond))
+	}
+}
+
+// Time adds a time field to the context
+func (cb *ContextBuilder) Time(key string, t time.Time) *ContextBuilder {
+	return cb.add(key, t)
+}
+
+// Err adds an error message to the context under the error field. A nil error is ignored.
+func (cb *ContextBuilder) Err(err error) *ContextBuilder {
+	if err == nil {
+		return cb
+	}
+	return cb.add(zerolog.ErrorFieldName, err.Error())
+}
+
+// Object adds a nested object field marshaled by obj to the context
+func (cb *ContextBuilder) Object(key string, obj LogObjectMarshaler) *ContextBuilder {
+	return cb.add(key, obj)
+}
+
+// AddField adds a field of any type to the context
+func (cb *ContextBuilder) AddField(key string, value any) *ContextBuilder {
+	return cb.add(key, value)
+}
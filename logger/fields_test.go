@@ -0,0 +1,216 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestKeyCollisionPolicy tests how WithFields handles keys already in the context
+func TestKeyCollisionPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy KeyCollisionPolicy
+		want   string
+	}{
+		{"overwrite", KeyCollisionOverwrite, `"region":"eu","user":"second","message":"msg"`},
+		{"keep first", KeyCollisionKeepFirst, `"region":"eu","user":"first","message":"msg"`},
+		{"suffix", KeyCollisionSuffix, `"region":"eu","user":"first","user_2":"second","user_3":"third","message":"msg"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := NewWithOptions(
+				WithOutput(&buf),
+				WithCaller(false),
+				WithTimestamp(false),
+				WithKeyCollision(tt.policy),
+			)
+
+			child := log.WithFields(map[string]any{"user": "first", "region": "eu"}).
+				WithFields(map[string]any{"user": "second"})
+			if tt.policy == KeyCollisionSuffix {
+				child = child.WithFields(map[string]any{"user": "third"})
+			}
+			child.InfoMsg("msg")
+
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Expected %s, got: %s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+// TestKeyCollisionParentUnchanged tests that child loggers do not modify their parent
+func TestKeyCollisionParentUnchanged(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithTimestamp(false))
+
+	parent := log.WithFields(map[string]any{"user": "parent"})
+	parent.WithFields(map[string]any{"user": "child"}).InfoMsg("child")
+	parent.InfoMsg("parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got: %s", buf.String())
+	}
+	if strings.Count(lines[0], `"user"`) != 1 || !strings.Contains(lines[0], `"user":"child"`) {
+		t.Errorf("Expected a single child user field, got: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"user":"parent"`) {
+		t.Errorf("Expected parent user field, got: %s", lines[1])
+	}
+}
+
+// TestKeyCollisionService tests that the service field can be overridden without duplicating it
+func TestKeyCollisionService(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false))
+
+	log.WithFields(map[string]any{"service": "worker"}).InfoMsg("msg")
+	if strings.Count(buf.String(), `"service"`) != 1 || !strings.Contains(buf.String(), `"service":"worker"`) {
+		t.Errorf("Expected a single overridden service field, got: %s", buf.String())
+	}
+
+	// The level still applies after rebuilding the context
+	buf.Reset()
+	child := log.WithFields(map[string]any{"key": "value"})
+	child.SetLevel(WarnLevel)
+	child.InfoMsg("filtered")
+	if buf.Len() > 0 {
+		t.Errorf("Expected info entry to be filtered, got: %s", buf.String())
+	}
+}
+
+// TestWithPrefix tests namespacing the fields of a child logger
+func TestWithPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithTimestamp(false))
+
+	db := log.WithFields(map[string]any{"request_id": "r1"}).
+		WithPrefix("db.").
+		WithFields(map[string]any{"system": "postgres"})
+	db.Info().
+		Str("query", "SELECT 1").
+		Int("rows", 1).
+		Dict("pool", func(d *LogBuilder) { d.Int("idle", 2) }).
+		Msg("query executed")
+
+	want := `"request_id":"r1","db.system":"postgres","db.query":"SELECT 1","db.rows":1,"db.pool":{"idle":2}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s, got: %s", want, buf.String())
+	}
+
+	// Nested prefixes are concatenated and the parent is unchanged
+	buf.Reset()
+	db.WithPrefix("pool.").Info().Int("size", 10).Msg("nested")
+	assertLogContains(t, buf.String(), `"db.pool.size":10`, "info")
+
+	buf.Reset()
+	log.Info().Str("query", "plain").Msg("parent")
+	assertLogContains(t, buf.String(), `"query":"plain"`, "info")
+}
+
+// TestWithout tests removing and replacing inherited context fields
+func TestWithout(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithTimestamp(false),
+		WithKeyCollision(KeyCollisionKeepFirst),
+	)
+
+	reqLog := log.WithFields(map[string]any{"request_id": "r1", "user_id": "anonymous"})
+	reqLog.Without("user_id").InfoMsg("dropped")
+	assertLogNotContains(t, buf.String(), "user_id")
+	assertLogContains(t, buf.String(), `"request_id":"r1"`, "info")
+
+	// Re-binding the user after authentication, regardless of the policy
+	buf.Reset()
+	reqLog.Without("user_id").WithFields(map[string]any{"user_id": "u42"}).InfoMsg("rebound")
+	if strings.Count(buf.String(), `"user_id"`) != 1 || !strings.Contains(buf.String(), `"user_id":"u42"`) {
+		t.Errorf("Expected a single rebound user_id, got: %s", buf.String())
+	}
+
+	// The parent keeps its fields
+	buf.Reset()
+	reqLog.InfoMsg("parent")
+	assertLogContains(t, buf.String(), `"user_id":"anonymous"`, "info")
+}
+
+// TestContextBuilder tests creating child loggers with typed context fields
+func TestContextBuilder(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithTimestamp(false))
+
+	child := log.With().
+		Str("request_id", "r1").
+		Int("attempt", 2).
+		Bool("retry", true).
+		Dur("budget", 1500*time.Millisecond).
+		Err(errors.New("previous failure")).
+		Object("user", testUser{ID: "u1", Role: "admin"}).
+		Logger()
+	child.InfoMsg("with context")
+
+	want := `"request_id":"r1","attempt":2,"retry":true,"budget":1500,"error":"previous failure","user":{"id":"u1","role":"admin"}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s, got: %s", want, buf.String())
+	}
+
+	// The prefix and collision policy apply as with WithFields
+	buf.Reset()
+	child.WithPrefix("db.").With().Str("query", "SELECT 1").Logger().
+		With().Str("request_id", "r2").Logger().
+		InfoMsg("prefixed")
+	assertLogContains(t, buf.String(), `"db.query":"SELECT 1"`, "info")
+	assertLogContains(t, buf.String(), `"db.request_id":"r2"`, "info")
+	assertLogContains(t, buf.String(), `"request_id":"r1"`, "info")
+}
+
+// TestCloneWith tests cloning a logger with a modified configuration
+func TestCloneWith(t *testing.T) {
+	skipWithoutDebug(t)
+
+	var buf, report bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithLevel(WarnLevel))
+	reqLog := log.WithFields(map[string]any{"request_id": "r1"}).WithPrefix("db.")
+
+	clone := reqLog.CloneWith(func(c *Config) {
+		c.Output = &report
+		c.Level = DebugLevel
+	})
+	clone.Debug().Str("query", "SELECT 1").Msg("report line")
+
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output on the original logger, got: %s", buf.String())
+	}
+	assertLogContains(t, report.String(), `"request_id":"r1"`, "debug")
+	assertLogContains(t, report.String(), `"db.query":"SELECT 1"`, "debug")
+
+	// The original logger is unchanged
+	reqLog.DebugMsg("filtered")
+	reqLog.WarnMsg("original")
+	if strings.Contains(buf.String(), "filtered") || !strings.Contains(buf.String(), "original") {
+		t.Errorf("Expected the original level to apply, got: %s", buf.String())
+	}
+
+	// The service field follows the new configuration
+	report.Reset()
+	clone = New(Config{Output: &buf, ServiceName: "orig"}).WithFields(map[string]any{"request_id": "r1"}).
+		CloneWith(func(c *Config) {
+			c.Output = &report
+			c.ServiceName = "report"
+			c.ServiceFieldName = "app"
+		})
+	clone.InfoMsg("renamed service")
+	if clone.ServiceName() != "report" {
+		t.Errorf("Expected service name report, got %s", clone.ServiceName())
+	}
+	assertLogContains(t, report.String(), `"app":"report"`, "info")
+	assertLogContains(t, report.String(), `"request_id":"r1"`, "")
+	assertLogNotContains(t, report.String(), "orig")
+}
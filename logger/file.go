@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultFileMode is the permission of the log files created by OpenFile
+const DefaultFileMode os.FileMode = 0o644
+
+// MaxAtomicEntrySize is the largest entry written by a shared FileWriter. POSIX
+// only guarantees that writes up to PIPE_BUF (4 KiB on Linux) are not
+// interleaved with writes of other processes on every file system.
+const MaxAtomicEntrySize = 4096
+
+// ErrEntryTooLarge is returned by a shared FileWriter for entries larger than MaxAtomicEntrySize
+var ErrEntryTooLarge = errors.New("logger: entry exceeds the atomic write size")
+
+// FileWriter is an io.Writer that appends the entries to a file. The file can
+// be reopened, so it works with external rotation such as logrotate without
+// copytruncate: logrotate renames the file and signals the process, which
+// reopens the path and starts a new file.
+type FileWriter struct {
+	path   string
+	shared bool
+
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+// OpenFile opens the file at path for appending, creating it if needed
+func OpenFile(path string) (*FileWriter, error) {
+	w := &FileWriter{path: path}
+	file, err := w.open()
+	if err != nil {
+		return nil, err
+	}
+	w.file = file
+	return w, nil
+}
+
+// OpenSharedFile opens a file that several processes append to, such as
+// replicas sharing a log file. The file is opened with O_APPEND and each entry
+// is written with a single write call, so entries are never interleaved.
+// Entries larger than MaxAtomicEntrySize are rejected with ErrEntryTooLarge,
+// since larger writes may be split; cap them with Config.MaxFields.
+func OpenSharedFile(path string) (*FileWriter, error) {
+	w, err := OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	w.shared = true
+	return w, nil
+}
+
+// open opens the file at the writer path
+func (w *FileWriter) open() (*os.File, error) {
+	return os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, DefaultFileMode)
+}
+
+// Write implements io.Writer. Each call is written with a single write to the
+// file, which is opened with O_APPEND.
+func (w *FileWriter) Write(p []byte) (int, error) {
+	if w.shared && len(p) > MaxAtomicEntrySize {
+		return 0, fmt.Errorf("%w (%d bytes)", ErrEntryTooLarge, len(p))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	return w.file.Write(p)
+}
+
+// Reopen closes the file and opens the path again. If the new file cannot be
+// opened, the writer keeps writing to the current one and the error is returned.
+func (w *FileWriter) Reopen() error {
+	file, err := w.open()
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		file.Close()
+		return ErrClosed
+	}
+	old := w.file
+	w.file = file
+	return old.Close()
+}
+
+// ReopenOnSignal calls Reopen every time one of the signals is received, until
+// the returned stop function is called. Reopen errors are passed to onError if
+// it is not nil. For logrotate, use the signal of its postrotate script:
+//
+//	stop := fw.ReopenOnSignal(nil, syscall.SIGHUP)
+//	defer stop()
+func (w *FileWriter) ReopenOnSignal(onError func(error), sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sig...)
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := w.Reopen(); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
+
+// Close closes the file
+func (w *FileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.file.Close()
+}
+
+// rotatedSuffix matches what rotation tools append to the log file name: a
+// separator, a number or date, and an optional compression extension, such as
+// .1, .2.gz or -20240101.gz
+var rotatedSuffix = regexp.MustCompile(`^[.\-_][0-9][0-9T:._\-]*(\.(gz|bz2|xz|zst|lz4|zip))?$`)
+
+// EnforceQuota deletes the rotated files of the log, oldest first, until the
+// total size of the current and rotated files is at most maxBytes. Rotated
+// files are the files in the same directory named after the log file with a
+// rotation suffix, such as app.log.1 or app.log-20240101.gz; other files, such
+// as app.logic.db, are left alone. The current file is never deleted.
+func (w *FileWriter) EnforceQuota(maxBytes int64) error {
+	current, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	total := current.Size()
+
+	dir, base := filepath.Split(w.path)
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return err
+	}
+	var archives []os.FileInfo
+	for _, e := range entries {
+		suffix, ok := strings.CutPrefix(e.Name(), base)
+		if e.IsDir() || !ok || !rotatedSuffix.MatchString(suffix) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		archives = append(archives, info)
+		total += info.Size()
+	}
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].ModTime().Before(archives[j].ModTime())
+	})
+
+	var errs []error
+	for _, info := range archives {
+		if total <= maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, info.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= info.Size()
+	}
+	return errors.Join(errs...)
+}
+
+// StartJanitor calls EnforceQuota every interval until the returned stop
+// function is called, keeping the log directory within a disk budget on
+// long-running hosts. Errors are passed to onError if it is not nil.
+func (w *FileWriter) StartJanitor(maxBytes int64, interval time.Duration, onError func(error)) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.StartJanitorContext(ctx, maxBytes, interval, onError)
+	return cancel
+}
+
+// StartJanitorContext is like StartJanitor, but the janitor runs until ctx is done
+func (w *FileWriter) StartJanitorContext(ctx context.Context, maxBytes int64, interval time.Duration, onError func(error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := w.EnforceQuota(maxBytes); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFileWriterReopen tests reopening the file after an external rotation
+func TestFileWriterReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile returned an error: %v", err)
+	}
+	defer fw.Close()
+	log := NewWithOptions(WithOutput(fw), WithCaller(false))
+
+	log.InfoMsg("before rotation")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	log.InfoMsg("still in rotated file")
+	if err := fw.Reopen(); err != nil {
+		t.Fatalf("Reopen returned an error: %v", err)
+	}
+	log.InfoMsg("after rotation")
+
+	rotated, _ := os.ReadFile(path + ".1")
+	current, _ := os.ReadFile(path)
+	if strings.Count(string(rotated), "\n") != 2 || !strings.Contains(string(rotated), "still in rotated file") {
+		t.Errorf("Unexpected rotated file: %s", rotated)
+	}
+	assertLogContains(t, string(current), "after rotation", "info")
+}
+
+// TestSharedFileWriter tests concurrent appends from several writers to the same file
+func TestSharedFileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.log")
+
+	// Loggers are created first, as New sets zerolog globals
+	loggers := make([]*Logger, 4)
+	for w := range loggers {
+		fw, err := OpenSharedFile(path)
+		if err != nil {
+			t.Fatalf("OpenSharedFile returned an error: %v", err)
+		}
+		defer fw.Close()
+		loggers[w] = NewWithOptions(WithOutput(fw), WithCaller(false))
+	}
+
+	var wg sync.WaitGroup
+	for w, log := range loggers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				log.Info().Int("writer", w).Int("i", i).Str("payload", strings.Repeat("x", 500)).Msg("entry")
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 400 {
+		t.Fatalf("Expected 400 entries, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var logData map[string]any
+		if err := json.Unmarshal([]byte(line), &logData); err != nil {
+			t.Fatalf("Corrupted entry %q: %v", line, err)
+		}
+	}
+
+	fw, _ := OpenSharedFile(path)
+	defer fw.Close()
+	if _, err := fw.Write(make([]byte, MaxAtomicEntrySize+1)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Errorf("Expected ErrEntryTooLarge, got %v", err)
+	}
+}
+
+// TestFileWriterQuota tests deleting the oldest rotated files over the quota
+func TestFileWriterQuota(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	fw, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile returned an error: %v", err)
+	}
+	defer fw.Close()
+	fw.Write([]byte(strings.Repeat("x", 100)))
+
+	// Rotated files, oldest first, and unrelated files, one sharing the prefix
+	now := time.Now()
+	names := []string{"app.logic.db", "app.log-20240101.gz", "app.log.3.gz", "app.log.2", "app.log.1", "other.log"}
+	for i, name := range names {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, make([]byte, 100), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i-len(names)) * time.Hour)
+		os.Chtimes(file, mtime, mtime)
+	}
+
+	if err := fw.EnforceQuota(250); err != nil {
+		t.Fatalf("EnforceQuota returned an error: %v", err)
+	}
+	for name, exists := range map[string]bool{
+		"app.log": true, "app.log.1": true, "app.log.2": false, "app.log.3.gz": false,
+		"app.log-20240101.gz": false, "app.logic.db": true, "other.log": true,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != exists {
+			t.Errorf("Expected %s to exist: %v", name, exists)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestFileWriterReopenOnSignal tests reopening the file on a signal
+func TestFileWriterReopenOnSignal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	fw, err := OpenFile(path)
+	if err != nil {
+		t.Fatalf("OpenFile returned an error: %v", err)
+	}
+	defer fw.Close()
+	stop := fw.ReopenOnSignal(func(err error) { t.Errorf("Reopen failed: %v", err) }, syscall.SIGHUP)
+	defer stop()
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("Expected the file to be reopened on SIGHUP")
+}
@@ -0,0 +1,115 @@
+package logger
+
+// Names of the command line flags registered by RegisterFlags.
+const (
+	FlagLogLevel  = "log-level"
+	FlagLogFormat = "log-format"
+	FlagLogFile   = "log-file"
+)
+
+// Usage of the command line flags registered by RegisterFlags.
+const (
+	flagLogLevelUsage  = "minimum log level (trace, debug, info, notice, warn, error, critical)"
+	flagLogFormatUsage = "log format (json, pretty, json-pretty, csv, ltsv, plain, github)"
+	flagLogFileUsage   = "append logs to this file instead of stderr"
+)
+
+// FlagSet is the part of *flag.FlagSet and *pflag.FlagSet, used by cobra,
+// needed by RegisterFlags
+type FlagSet interface {
+	String(name, value, usage string) *string
+}
+
+// Flags holds the values of the flags registered by RegisterFlags
+type Flags struct {
+	Level  *string
+	Format *string
+	File   *string
+}
+
+// RegisterFlags defines the --log-level, --log-format and --log-file flags on
+// fs, so every command line exposes the same logging flags. With cobra:
+//
+//	flags := logger.RegisterFlags(rootCmd.PersistentFlags())
+//	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) (err error) {
+//		log, err = logger.NewFromFlags(flags)
+//		return err
+//	}
+func RegisterFlags(fs FlagSet) *Flags {
+	specs := CLIFlags()
+	values := make([]*string, len(specs))
+	for i, f := range specs {
+		values[i] = fs.String(f.Name, f.Value, f.Usage)
+	}
+	return &Flags{Level: values[0], Format: values[1], File: values[2]}
+}
+
+// NewFromFlags creates a logger from the flags registered by RegisterFlags,
+// once they are parsed. It returns an error if a flag is invalid or the log
+// file cannot be opened.
+func NewFromFlags(f *Flags) (*Logger, error) {
+	values := map[string]string{
+		SettingLevel:  *f.Level,
+		SettingFormat: *f.Format,
+		SettingFile:   *f.File,
+	}
+	cfg, err := configFromSettings(func(name string) (string, bool) {
+		s, ok := values[name]
+		return s, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg), nil
+}
+
+// FlagSpec describes a standard logging flag, to declare it in command line
+// frameworks
+type FlagSpec struct {
+	Name  string
+	Value string
+	Usage string
+}
+
+// CLIFlags returns the standard logging flags registered by RegisterFlags, to
+// declare them in frameworks such as urfave/cli without depending on them here:
+//
+//	for _, f := range logger.CLIFlags() {
+//		app.Flags = append(app.Flags, &cli.StringFlag{Name: f.Name, Value: f.Value, Usage: f.Usage})
+//	}
+func CLIFlags() []FlagSpec {
+	return []FlagSpec{
+		{FlagLogLevel, InfoLevel.String(), flagLogLevelUsage},
+		{FlagLogFormat, "json", flagLogFormatUsage},
+		{FlagLogFile, "", flagLogFileUsage},
+	}
+}
+
+// CLIContext is the part of urfave/cli's *cli.Context (v2) and *cli.Command
+// (v3) needed by NewFromCLIContext
+type CLIContext interface {
+	String(name string) string
+}
+
+// NewFromCLIContext creates a logger from the flags declared with CLIFlags.
+// Flags that are not declared or empty keep their DefaultConfig value. It
+// returns an error if a flag is invalid or the log file cannot be opened.
+func NewFromCLIContext(c CLIContext) (*Logger, error) {
+	names := map[string]string{
+		SettingLevel:  FlagLogLevel,
+		SettingFormat: FlagLogFormat,
+		SettingFile:   FlagLogFile,
+	}
+	cfg, err := configFromSettings(func(name string) (string, bool) {
+		flag, ok := names[name]
+		if !ok {
+			return "", false
+		}
+		s := c.String(flag)
+		return s, s != ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg), nil
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFlags tests building a logger from the standard logging flags
+func TestFlags(t *testing.T) {
+	skipWithoutDebug(t)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse([]string{"--log-level", "debug", "--log-file", path}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	log, err := NewFromFlags(flags)
+	if err != nil {
+		t.Fatalf("NewFromFlags failed: %v", err)
+	}
+	defer log.cfg.Output.(*FileWriter).Close()
+
+	log.Debug().Msg("debug message")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read the log file: %v", err)
+	}
+	assertLogContains(t, string(data), "debug message", "debug")
+
+	// Defaults are valid
+	fs = flag.NewFlagSet("app", flag.ContinueOnError)
+	flags = RegisterFlags(fs)
+	if _, err := NewFromFlags(flags); err != nil {
+		t.Errorf("Expected the default flags to be valid, got %v", err)
+	}
+
+	*flags.Format = "xml"
+	if _, err := NewFromFlags(flags); err == nil {
+		t.Error("Expected an invalid format error")
+	}
+}
+
+// cliContext is a CLIContext backed by a map of flag values
+type cliContext map[string]string
+
+func (c cliContext) String(name string) string {
+	return c[name]
+}
+
+// TestCLIFlags tests building a logger from urfave/cli style flags
+func TestCLIFlags(t *testing.T) {
+	values := cliContext{}
+	for _, f := range CLIFlags() {
+		values[f.Name] = f.Value
+	}
+	values[FlagLogLevel] = "error"
+
+	log, err := NewFromCLIContext(values)
+	if err != nil {
+		t.Fatalf("NewFromCLIContext failed: %v", err)
+	}
+	if log.Enabled(WarnLevel) || !log.Enabled(ErrorLevel) {
+		t.Error("Expected the error level from the flags")
+	}
+
+	// Undeclared flags keep the defaults
+	log, err = NewFromCLIContext(cliContext{})
+	if err != nil || !log.Enabled(InfoLevel) {
+		t.Errorf("Expected the default configuration, got %v", err)
+	}
+
+	if _, err := NewFromCLIContext(cliContext{FlagLogLevel: "loud"}); err == nil {
+		t.Error("Expected an invalid level error")
+	}
+}
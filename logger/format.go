@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the output format of a logger
+type Format string
+
+// Output formats supported by Config.Format.
+const (
+	// FormatJSON writes each entry as a single-line JSON object
+	FormatJSON Format = "json"
+	// FormatPretty writes each entry as a colored, human-readable line
+	FormatPretty Format = "pretty"
+	// FormatJSONPretty writes each entry as indented, multi-line JSON
+	FormatJSONPretty Format = "json-pretty"
+	// FormatCSV writes each entry as a CSV record with the Config.CSVColumns
+	FormatCSV Format = "csv"
+	// FormatLTSV writes each entry as Labeled Tab-Separated Values
+	FormatLTSV Format = "ltsv"
+	// FormatPlain writes each entry as an aligned key=value line without
+	// colors, for CI logs and kubectl logs
+	FormatPlain Format = "plain"
+	// FormatGitHub writes warn and higher entries as GitHub Actions
+	// annotations and the other entries like FormatPlain
+	FormatGitHub Format = "github"
+)
+
+// formatAliases maps alternative format names to their format
+var formatAliases = map[string]Format{
+	"console": FormatPretty,
+}
+
+// ParseFormat parses a format name, case-insensitively. "console" is accepted
+// as an alias of "pretty".
+func ParseFormat(s string) (Format, error) {
+	name := strings.ToLower(s)
+	switch f := Format(name); f {
+	case FormatJSON, FormatPretty, FormatJSONPretty, FormatCSV, FormatLTSV, FormatPlain, FormatGitHub:
+		return f, nil
+	}
+	if f, ok := formatAliases[name]; ok {
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid log format: %s", s)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so formats are validated
+// when decoded from configuration files
+func (f *Format) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*f = ""
+		return nil
+	}
+	parsed, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// format returns the output format of the configuration: Format if set,
+// otherwise FormatPretty if Pretty is enabled, or FormatJSON
+func (c Config) format() Format {
+	switch {
+	case c.Format != "":
+		return c.Format
+	case c.Pretty:
+		return FormatPretty
+	}
+	return FormatJSON
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestParseFormat tests parsing format names and aliases
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+	}{
+		{"json", FormatJSON},
+		{"pretty", FormatPretty},
+		{"console", FormatPretty},
+		{"JSON-Pretty", FormatJSONPretty},
+	}
+	for _, tt := range tests {
+		format, err := ParseFormat(tt.input)
+		if err != nil || format != tt.expected {
+			t.Errorf("ParseFormat(%q) = %q, %v, expected %q", tt.input, format, err, tt.expected)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"format":"json-pretty"}`), &cfg); err != nil || cfg.format() != FormatJSONPretty {
+		t.Errorf("Expected the decoded format, got %q, %v", cfg.Format, err)
+	}
+	if err := json.Unmarshal([]byte(`{"format":"xml"}`), &cfg); err == nil {
+		t.Error("Expected an error decoding an unknown format")
+	}
+}
+
+// TestJSONPrettyFormat tests writing entries as indented JSON
+func TestJSONPrettyFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithFormat(FormatJSONPretty), WithCaller(false))
+
+	log.Info().Dict("request", func(d *LogBuilder) {
+		d.Str("method", "GET").Int("status", 200)
+	}).Msg("handled")
+
+	output := buf.String()
+	if !strings.Contains(output, "{\n  \"level\": \"info\",") {
+		t.Errorf("Expected indented JSON, got:\n%s", output)
+	}
+	if !strings.Contains(output, "\n    \"method\": \"GET\"") {
+		t.Errorf("Expected nested fields indented twice, got:\n%s", output)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON entry, got %v:\n%s", err, output)
+	}
+	if entry["message"] != "handled" {
+		t.Errorf("Expected the message, got %v", entry["message"])
+	}
+}
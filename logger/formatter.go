@@ -1,6 +1,9 @@
 package logger
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/rs/zerolog"
@@ -19,24 +22,82 @@ func (f JSONFormatter) Format(w io.Writer) io.Writer {
 	return w
 }
 
+// IndentedJSONFormatter formats logs as indented, multi-line JSON, for reading
+// deeply nested entries with full fidelity.
+type IndentedJSONFormatter struct {
+	// Indent is the indentation of each nesting level, two spaces if empty
+	Indent string
+}
+
+// Format returns a writer that indents each JSON entry. Entries that are not
+// valid JSON are written unchanged.
+func (f IndentedJSONFormatter) Format(w io.Writer) io.Writer {
+	indent := f.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	return indentWriter{out: w, indent: indent}
+}
+
+// indentWriter writes each JSON entry indented
+type indentWriter struct {
+	out    io.Writer
+	indent string
+}
+
+// Write implements io.Writer
+func (w indentWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(p), "", w.indent); err != nil {
+		return w.out.Write(p)
+	}
+	buf.WriteByte('\n')
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 // PrettyFormatter formats logs in a human-readable format.
 type PrettyFormatter struct {
 	// NoColor disables colors in the output
 	NoColor bool
 	// TimeFormat sets the format for timestamps
 	TimeFormat string
+	// ExpandStack renders the stack trace field with one frame per line
+	ExpandStack bool
+	// Palette sets the level colors, PaletteDefault if empty
+	Palette Palette
+	// LevelIcons prefixes the levels with a glyph or string per level
+	LevelIcons map[Level]string
 }
 
 // Format returns a writer that formats logs in a pretty, human-readable format.
 func (f PrettyFormatter) Format(w io.Writer) io.Writer {
 	output := zerolog.ConsoleWriter{
-		Out:        w,
-		NoColor:    f.NoColor,
-		TimeFormat: f.TimeFormat,
+		Out:         w,
+		NoColor:     f.NoColor,
+		TimeFormat:  f.TimeFormat,
+		FormatLevel: formatLevel(f.NoColor, f.Palette, f.LevelIcons),
+	}
+	if f.ExpandStack {
+		output.FieldsExclude = []string{zerolog.ErrorStackFieldName}
+		output.FormatExtra = formatStack
 	}
 	return output
 }
 
+// formatStack writes the frames of the stack trace field on their own lines
+func formatStack(evt map[string]any, buf *bytes.Buffer) error {
+	frames, _ := evt[zerolog.ErrorStackFieldName].([]any)
+	for _, frame := range frames {
+		if f, ok := frame.(map[string]any); ok {
+			fmt.Fprintf(buf, "\n    at %v (%v:%v)", f["func"], f["source"], f["line"])
+		}
+	}
+	return nil
+}
+
 // DefaultJSONFormatter returns a new JSONFormatter with default settings.
 func DefaultJSONFormatter() Formatter {
 	return JSONFormatter{}
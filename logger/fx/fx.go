@@ -0,0 +1,37 @@
+// Package loggerfx provides the logger to fx applications. It is a separate
+// module, so only the applications using fx depend on it:
+//
+//	fx.New(
+//		loggerfx.Module,
+//		fx.Invoke(func(log *logger.Logger) { ... }),
+//	)
+package loggerfx
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// Module provides a *logger.Logger configured from environment variables,
+// like logger.NewFromEnv, and shuts it down when the application stops
+var Module = fx.Module("logger",
+	fx.Provide(func(lc fx.Lifecycle) *logger.Logger {
+		return withLifecycle(lc, logger.NewFromEnv())
+	}),
+)
+
+// ConfigModule provides a *logger.Logger created from the logger.Config
+// provided by the application, and shuts it down when the application stops
+var ConfigModule = fx.Module("logger",
+	fx.Provide(func(lc fx.Lifecycle, cfg logger.Config) *logger.Logger {
+		return withLifecycle(lc, logger.New(cfg))
+	}),
+)
+
+// withLifecycle shuts the logger down when the application stops. Shutdown
+// flushes and closes the output, giving up at the stop deadline.
+func withLifecycle(lc fx.Lifecycle, l *logger.Logger) *logger.Logger {
+	lc.Append(fx.Hook{OnStop: l.Shutdown})
+	return l
+}
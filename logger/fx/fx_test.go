@@ -0,0 +1,46 @@
+package loggerfx
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// TestConfigModule tests providing the logger and closing it on stop
+func TestConfigModule(t *testing.T) {
+	file, err := logger.OpenFile(filepath.Join(t.TempDir(), "app.log"))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+
+	var log *logger.Logger
+	app := fxtest.New(t,
+		fx.Supply(logger.Config{Level: logger.InfoLevel, Output: file}),
+		ConfigModule,
+		fx.Populate(&log),
+	)
+	app.RequireStart()
+	log.Info().Msg("running")
+	app.RequireStop()
+
+	if _, err := file.Write([]byte("{}\n")); !errors.Is(err, logger.ErrClosed) {
+		t.Errorf("Expected the output to be closed on stop, got %v", err)
+	}
+}
+
+// TestModule tests providing the logger from the environment
+func TestModule(t *testing.T) {
+	t.Setenv(logger.EnvLogLevel, "warn")
+
+	var log *logger.Logger
+	app := fxtest.New(t, Module, fx.Populate(&log))
+	app.RequireStart().RequireStop()
+	if log == nil {
+		t.Fatal("Expected a logger")
+	}
+}
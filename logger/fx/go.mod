@@ -0,0 +1,18 @@
+module github.com/jdroa1998/easy-logger/logger/fx
+
+go 1.24.1
+
+require (
+	github.com/jdroa1998/easy-logger v0.0.0-20261016030613-2f95288b0e2e
+	go.uber.org/fx v1.23.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/rs/zerolog v1.33.0 // indirect
+	go.uber.org/dig v1.18.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	go.uber.org/zap v1.26.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+)
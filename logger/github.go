@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// githubDataEscaper escapes the message of a workflow command
+var githubDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// githubPropertyEscaper escapes the property values of a workflow command
+var githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+// GitHubActionsFormatter formats warn and higher entries as GitHub Actions
+// workflow commands, so they are shown as annotations of the run:
+//
+//	::error file=internal/api/handler.go,line=42::request failed status=500
+//
+// Warn entries become warnings and error and higher entries become errors,
+// located at the caller of the entry. The other entries are written with
+// Formatter.
+type GitHubActionsFormatter struct {
+	// Formatter writes the entries below warn. Defaults to PlainFormatter
+	Formatter Formatter
+}
+
+// Format returns a writer that converts warn and higher JSON entries to
+// workflow commands
+func (f GitHubActionsFormatter) Format(w io.Writer) io.Writer {
+	formatter := f.Formatter
+	if formatter == nil {
+		formatter = PlainFormatter{}
+	}
+	return githubWriter{out: w, fallback: formatter.Format(w)}
+}
+
+// githubWriter writes annotations for warn and higher entries, and the other
+// entries to fallback
+type githubWriter struct {
+	out      io.Writer
+	fallback io.Writer
+}
+
+// Write implements io.Writer
+func (w githubWriter) Write(p []byte) (int, error) {
+	fields, err := parseEntry(p)
+	if err != nil {
+		return w.fallback.Write(p)
+	}
+	command := ""
+	var message, caller string
+	rest := make([]entryField, 0, len(fields))
+	for _, f := range fields {
+		switch f.key {
+		case zerolog.LevelFieldName:
+			command = githubCommand(textValue(f.value))
+		case zerolog.MessageFieldName:
+			message = textValue(f.value)
+		case zerolog.CallerFieldName:
+			caller = textValue(f.value)
+		case zerolog.TimestampFieldName:
+		default:
+			rest = append(rest, f)
+		}
+	}
+	if command == "" {
+		return w.fallback.Write(p)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("::" + command)
+	if file, line, ok := splitCaller(caller); ok {
+		buf.WriteString(" file=" + githubPropertyEscaper.Replace(file))
+		buf.WriteString(",line=" + githubPropertyEscaper.Replace(line))
+	}
+	buf.WriteString("::")
+	text := message
+	for _, f := range rest {
+		text += " " + f.key + "=" + plainValue(textValue(f.value))
+	}
+	buf.WriteString(githubDataEscaper.Replace(strings.TrimSpace(text)))
+	buf.WriteByte('\n')
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// githubCommand returns the workflow command of a level: "warning" for warn,
+// "error" for error and higher, empty for the lower levels
+func githubCommand(name string) string {
+	level, err := ParseLevel(name)
+	switch {
+	case err != nil || level.Severity() < WarnLevel.Severity():
+		return ""
+	case level.Severity() < ErrorLevel.Severity():
+		return "warning"
+	}
+	return "error"
+}
+
+// splitCaller splits a "file:line" caller, making the file relative to the
+// GitHub workspace when it is inside it
+func splitCaller(caller string) (file, line string, ok bool) {
+	i := strings.LastIndexByte(caller, ':')
+	if i <= 0 {
+		return "", "", false
+	}
+	file, line = caller[:i], caller[i+1:]
+	if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
+		if rel, err := filepath.Rel(workspace, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+	return file, line, true
+}
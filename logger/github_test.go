@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGitHubFormat tests writing warn and higher entries as annotations
+func TestGitHubFormat(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", filepath.Dir(packageDir))
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf, Format: FormatGitHub, DisableTimestamp: true, WithCaller: true})
+
+	log.Info().Msg("starting")
+	log.Warn().Int("attempt", 2).Msg("retrying")
+	log.Error().Str("path", "/a,b").Msg("50% failed\nfor good")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "INFO     starting") {
+		t.Errorf("Expected the info entry in plain format, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "::warning file=logger/github_test.go,line=") ||
+		!strings.HasSuffix(lines[1], "::retrying service=UNKNOWN-SERVICE attempt=2") {
+		t.Errorf("Unexpected warning annotation %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "::error file=logger/github_test.go,line=") ||
+		!strings.HasSuffix(lines[2], "::50%25 failed%0Afor good service=UNKNOWN-SERVICE path=/a,b") {
+		t.Errorf("Unexpected error annotation %q", lines[2])
+	}
+}
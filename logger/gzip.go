@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultGzipFlushInterval is the flush interval used by NewGzipWriter for a zero GzipConfig value
+const DefaultGzipFlushInterval = time.Second
+
+// GzipConfig sets how a GzipWriter compresses and flushes the stream
+type GzipConfig struct {
+	// Level is the compression level, gzip.DefaultCompression if zero
+	Level int
+	// FlushInterval is how often the compressed data is flushed to the output,
+	// so the stream can be read while it is written
+	FlushInterval time.Duration
+}
+
+// GzipWriter is an io.Writer that gzips the log stream for high-volume logging
+// to disk. Compressed data is sync-flushed every FlushInterval and after
+// every error, fatal or panic entry, so the stream can be decompressed up to
+// the last flush even if the process dies:
+//
+//	gz, err := logger.NewGzipWriter(file, logger.GzipConfig{})
+//	defer gz.Close()
+//	log := logger.NewWithOptions(logger.WithOutput(gz))
+type GzipWriter struct {
+	mu     sync.Mutex
+	gz     *gzip.Writer
+	closed bool
+	stop   chan struct{}
+}
+
+// NewGzipWriter creates a GzipWriter writing the compressed stream to out. It
+// returns an error if the compression level is invalid.
+func NewGzipWriter(out io.Writer, cfg GzipConfig) (*GzipWriter, error) {
+	if cfg.Level == 0 {
+		cfg.Level = gzip.DefaultCompression
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = DefaultGzipFlushInterval
+	}
+	gz, err := gzip.NewWriterLevel(out, cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	w := &GzipWriter{gz: gz, stop: make(chan struct{})}
+	go w.flushEvery(cfg.FlushInterval)
+	return w, nil
+}
+
+// NewGzipWriterContext creates a GzipWriter like NewGzipWriter that is closed,
+// ending the gzip stream and its flush goroutine, once ctx is done
+func NewGzipWriterContext(ctx context.Context, out io.Writer, cfg GzipConfig) (*GzipWriter, error) {
+	w, err := NewGzipWriter(out, cfg)
+	if err != nil {
+		return nil, err
+	}
+	closeOnDone(ctx, w, nil)
+	return w, nil
+}
+
+// Write implements io.Writer
+func (w *GzipWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, ErrClosed
+	}
+	n, err := w.gz.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if isErrorEntry(p) {
+		err = w.gz.Flush()
+	}
+	return n, err
+}
+
+// Flush writes the pending compressed data to the output
+func (w *GzipWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	return w.gz.Flush()
+}
+
+// Close writes the end of the gzip stream. It does not close the output.
+func (w *GzipWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	close(w.stop)
+	return w.gz.Close()
+}
+
+// flushEvery flushes the stream periodically until the writer is closed
+func (w *GzipWriter) flushEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.Flush()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// isErrorEntry reports whether the JSON entry is at error level or above
+func isErrorEntry(p []byte) bool {
+	for _, level := range []string{zerolog.LevelErrorValue, CriticalLevel.String(), zerolog.LevelFatalValue, zerolog.LevelPanicValue} {
+		if bytes.Contains(p, []byte(`"`+zerolog.LevelFieldName+`":"`+level+`"`)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// readGzipLines decompresses the available part of a gzip stream
+func readGzipLines(t *testing.T, data []byte) []string {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Could not read gzip stream: %v", err)
+	}
+	var lines []string
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil && err != io.ErrUnexpectedEOF {
+		t.Fatalf("Could not decompress: %v", err)
+	}
+	return lines
+}
+
+// TestGzipWriter tests compressing the log stream and flushing on error entries
+func TestGzipWriter(t *testing.T) {
+	var out syncBuffer
+	gz, err := NewGzipWriter(&out, GzipConfig{FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewGzipWriter returned an error: %v", err)
+	}
+	log := NewWithOptions(WithOutput(gz), WithCaller(false))
+
+	log.InfoMsg("buffered")
+	log.ErrorMsg("flushed")
+	lines := readGzipLines(t, out.Bytes())
+	if len(lines) != 2 || !strings.Contains(lines[1], "flushed") {
+		t.Fatalf("Expected the stream to be flushed on the error entry, got %q", lines)
+	}
+
+	log.InfoMsg("closed")
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+	if lines := readGzipLines(t, out.Bytes()); len(lines) != 3 {
+		t.Errorf("Expected 3 entries after Close, got %q", lines)
+	}
+	if _, err := gz.Write([]byte("{}\n")); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+// TestGzipWriterInterval tests the periodic flush
+func TestGzipWriterInterval(t *testing.T) {
+	var out syncBuffer
+	gz, err := NewGzipWriter(&out, GzipConfig{FlushInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewGzipWriter returned an error: %v", err)
+	}
+	defer gz.Close()
+	log := NewWithOptions(WithOutput(gz), WithCaller(false))
+
+	log.InfoMsg("periodic")
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if len(out.Bytes()) > 0 && len(readGzipLines(t, out.Bytes())) == 1 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("Expected the entry to be flushed within the interval")
+}
+
+// TestGzipWriterContext tests ending the stream when the context is cancelled
+func TestGzipWriterContext(t *testing.T) {
+	var out syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	gz, err := NewGzipWriterContext(ctx, &out, GzipConfig{FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewGzipWriterContext failed: %v", err)
+	}
+	gz.Write([]byte(`{"level":"info","message":"last"}` + "\n"))
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := gz.Write([]byte("{}\n")); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the writer to be closed when the context is cancelled")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		t.Fatalf("Could not read gzip stream: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil || !strings.Contains(string(data), "last") {
+		t.Errorf("Expected a complete stream, got %q, %v", data, err)
+	}
+}
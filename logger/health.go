@@ -0,0 +1,50 @@
+package logger
+
+import "fmt"
+
+// HealthOutputName is the key of the logger output in the Health report
+const HealthOutputName = "output"
+
+// HealthChecker is implemented by sinks that can report their health, such as Batcher
+type HealthChecker interface {
+	// Health returns the last error of the sink, or nil if it is healthy
+	Health() error
+}
+
+// SinkError is returned by the health check of a sink that failed to write
+type SinkError struct {
+	// Err is the last error returned by the sink
+	Err error
+	// Backlog is the number of entries waiting to be written
+	Backlog int
+}
+
+// Error implements error
+func (e *SinkError) Error() string {
+	return fmt.Sprintf("%v (%d entries pending)", e.Err, e.Backlog)
+}
+
+// Unwrap returns the sink error
+func (e *SinkError) Unwrap() error {
+	return e.Err
+}
+
+// Health reports the health of the logger output, if it implements
+// HealthChecker, and of the sinks added with WithHealthCheck. A nil error means
+// the sink is healthy; use it in readiness probes:
+//
+//	for name, err := range log.Health() {
+//		if err != nil {
+//			return fmt.Errorf("log sink %s: %w", name, err)
+//		}
+//	}
+func (l *Logger) Health() map[string]error {
+	health := make(map[string]error, len(l.cfg.HealthChecks)+1)
+	if hc, ok := l.cfg.Output.(HealthChecker); ok {
+		health[HealthOutputName] = hc.Health()
+	}
+	for name, hc := range l.cfg.HealthChecks {
+		health[name] = hc.Health()
+	}
+	return health
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestHealth tests reporting the health of the output and added sinks
+func TestHealth(t *testing.T) {
+	rec := &batchRecorder{err: errors.New("connection refused")}
+	b := NewBatcher(rec.flush, BatchConfig{MaxEntries: 2})
+	defer b.Close()
+	audit := NewBatcher((&batchRecorder{}).flush, BatchConfig{MaxEntries: 1})
+	defer audit.Close()
+
+	log := NewWithOptions(WithOutput(b), WithCaller(false), WithHealthCheck("audit", audit))
+
+	health := log.Health()
+	if len(health) != 2 || health[HealthOutputName] != nil || health["audit"] != nil {
+		t.Fatalf("Expected healthy sinks, got %v", health)
+	}
+
+	log.InfoMsg("first")
+	log.InfoMsg("second")
+	log.InfoMsg("pending")
+	var sinkErr *SinkError
+	if err := log.Health()[HealthOutputName]; !errors.As(err, &sinkErr) || !errors.Is(err, rec.err) {
+		t.Fatalf("Expected a SinkError, got %v", err)
+	}
+	if sinkErr.Backlog != 1 {
+		t.Errorf("Expected a backlog of 1, got %d", sinkErr.Backlog)
+	}
+
+	// The sink is healthy again after a successful flush
+	rec.mu.Lock()
+	rec.err = nil
+	rec.mu.Unlock()
+	if err := b.Flush(); err != nil {
+		t.Fatalf("Flush returned an error: %v", err)
+	}
+	if err := log.Health()[HealthOutputName]; err != nil {
+		t.Errorf("Expected a healthy sink, got %v", err)
+	}
+}
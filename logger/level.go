@@ -1,8 +1,12 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 )
@@ -25,48 +29,312 @@ const (
 	PanicLevel Level = Level(zerolog.PanicLevel)
 	// TraceLevel defines trace log level.
 	TraceLevel Level = Level(zerolog.TraceLevel)
+	// NoticeLevel defines notice log level, between info and warn.
+	NoticeLevel Level = 8
+	// CriticalLevel defines critical log level, between error and fatal.
+	CriticalLevel Level = 9
+	// NoLevel is the level of entries without a level field, written by Log.
+	NoLevel Level = Level(zerolog.NoLevel)
 )
 
-// ParseLevel converts a level string to a Level.
+// SeverityFieldName is the key of the numeric syslog severity added with Config.SyslogSeverity
+const SeverityFieldName = "severity"
+
+// Level colors used by the pretty formatters.
+const (
+	colorRed     = 31
+	colorGreen   = 32
+	colorYellow  = 33
+	colorBlue    = 34
+	colorMagenta = 35
+	colorCyan    = 36
+)
+
+// levelInfo describes a level
+type levelInfo struct {
+	// name is the value of the level field
+	name string
+	// severity orders the levels
+	severity int
+	// formatted is the short name used by the pretty formatters
+	formatted string
+	// color is the ANSI color used by the pretty formatters, none if zero
+	color int
+	// zlevel is the zerolog level the entries are written at: the level itself
+	// for zerolog levels, and the closest lower zerolog level otherwise
+	zlevel zerolog.Level
+}
+
+// levels describes the built-in levels
+var levels = map[Level]levelInfo{
+	TraceLevel:    {"trace", -10, "TRC", colorBlue, zerolog.TraceLevel},
+	DebugLevel:    {"debug", 0, "DBG", 0, zerolog.DebugLevel},
+	InfoLevel:     {"info", 10, "INF", colorGreen, zerolog.InfoLevel},
+	NoticeLevel:   {"notice", 15, "NTC", colorCyan, zerolog.InfoLevel},
+	WarnLevel:     {"warn", 20, "WRN", colorYellow, zerolog.WarnLevel},
+	ErrorLevel:    {"error", 30, "ERR", colorRed, zerolog.ErrorLevel},
+	CriticalLevel: {"critical", 35, "CRT", colorMagenta, zerolog.ErrorLevel},
+	FatalLevel:    {"fatal", 40, "FTL", colorRed, zerolog.FatalLevel},
+	PanicLevel:    {"panic", 50, "PNC", colorRed, zerolog.PanicLevel},
+}
+
+// customLevels holds the levels registered with RegisterLevel
+var (
+	customLevelsMu  sync.RWMutex
+	customLevels    = map[Level]levelInfo{}
+	hasCustomLevels atomic.Bool
+	nextCustomLevel = CriticalLevel + 1
+	levelAliases    = map[string]Level{"warning": WarnLevel}
+)
+
+// RegisterLevel registers a custom level and returns it. The level is
+// written with the given name, filtered by severity on the same scale as the
+// built-in levels (see Level.Severity), and rendered by the pretty formatters
+// with the first three letters of its name in the given ANSI color, such as
+// 35 for magenta, or uncolored if zero:
+//
+//	var AuditLevel, _ = logger.RegisterLevel("audit", 25, 35)
+//
+//	log.WithLevel(AuditLevel).Str("user", id).Msg("role changed")
+//
+// Entries at a custom level with a severity of at least 30 get a stack trace
+// like error entries. It returns an error if the name is empty or taken.
+func RegisterLevel(name string, severity int, color int) (Level, error) {
+	name = strings.ToLower(name)
+	if name == "" {
+		return 0, errors.New("logger: empty level name")
+	}
+
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	if _, ok := parseLevelLocked(name); ok {
+		return 0, fmt.Errorf("logger: level %s already exists", name)
+	}
+	if nextCustomLevel == Level(127) {
+		return 0, errors.New("logger: too many custom levels")
+	}
+	level := nextCustomLevel
+	nextCustomLevel++
+
+	zlevel := zerolog.TraceLevel
+	for z := zerolog.TraceLevel; z <= zerolog.PanicLevel; z++ {
+		if levels[Level(z)].severity <= severity {
+			zlevel = z
+		}
+	}
+	formatted := strings.ToUpper(name)
+	if len(formatted) > 3 {
+		formatted = formatted[:3]
+	}
+	customLevels[level] = levelInfo{name, severity, formatted, color, zlevel}
+	hasCustomLevels.Store(true)
+	return level, nil
+}
+
+// RegisterLevelAlias registers another name accepted by ParseLevel, and so by
+// NewFromEnv, for a level. It eases the migration from other logging stacks:
+//
+//	logger.RegisterLevelAlias("verbose", logger.DebugLevel)
+//	logger.RegisterLevelAlias("crit", logger.FatalLevel)
+//
+// Aliases are case-insensitive and entries keep the level name. It returns an
+// error if the alias is empty or already a level name or alias.
+func RegisterLevelAlias(alias string, level Level) error {
+	alias = strings.ToLower(alias)
+	if alias == "" {
+		return errors.New("logger: empty level alias")
+	}
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	if _, ok := parseLevelLocked(alias); ok {
+		return fmt.Errorf("logger: level %s already exists", alias)
+	}
+	levelAliases[alias] = level
+	return nil
+}
+
+// lookupLevel returns the description of a built-in or custom level
+func lookupLevel(l Level) (levelInfo, bool) {
+	if info, ok := levels[l]; ok {
+		return info, true
+	}
+	if !hasCustomLevels.Load() {
+		return levelInfo{}, false
+	}
+	customLevelsMu.RLock()
+	defer customLevelsMu.RUnlock()
+	info, ok := customLevels[l]
+	return info, ok
+}
+
+// ParseLevel converts a level string to a Level. The string is a level name
+// or alias, or the number of a level in zerolog numbering, such as "0" for
+// debug or "-1" for trace.
 // Returns an error if the level string is invalid.
 func ParseLevel(levelStr string) (Level, error) {
 	levelStr = strings.ToLower(levelStr)
-	switch levelStr {
-	case "debug":
-		return DebugLevel, nil
-	case "info":
-		return InfoLevel, nil
-	case "warn", "warning":
-		return WarnLevel, nil
-	case "error":
-		return ErrorLevel, nil
-	case "fatal":
-		return FatalLevel, nil
-	case "panic":
-		return PanicLevel, nil
-	case "trace":
-		return TraceLevel, nil
+	customLevelsMu.RLock()
+	defer customLevelsMu.RUnlock()
+	if level, ok := parseLevelLocked(levelStr); ok {
+		return level, nil
 	}
 	return InfoLevel, fmt.Errorf("invalid log level: %s", levelStr)
 }
 
+// parseLevelLocked looks up a lowercase level name, alias or number like
+// ParseLevel. The caller must hold customLevelsMu.
+func parseLevelLocked(levelStr string) (Level, bool) {
+	if n, err := strconv.ParseInt(levelStr, 10, 8); err == nil {
+		if _, ok := levels[Level(n)]; ok {
+			return Level(n), true
+		}
+		if _, ok := customLevels[Level(n)]; ok {
+			return Level(n), true
+		}
+	}
+	for level, info := range levels {
+		if info.name == levelStr {
+			return level, true
+		}
+	}
+	for level, info := range customLevels {
+		if info.name == levelStr {
+			return level, true
+		}
+	}
+	level, ok := levelAliases[levelStr]
+	return level, ok
+}
+
 // String converts a Level to a string.
 func (l Level) String() string {
-	switch l {
-	case DebugLevel:
-		return "debug"
-	case InfoLevel:
-		return "info"
-	case WarnLevel:
-		return "warn"
-	case ErrorLevel:
-		return "error"
-	case FatalLevel:
-		return "fatal"
-	case PanicLevel:
-		return "panic"
-	case TraceLevel:
-		return "trace"
+	if info, ok := lookupLevel(l); ok {
+		return info.name
+	}
+	if l == NoLevel {
+		return ""
 	}
 	return "unknown"
 }
+
+// Set implements flag.Value, so a Level can be used with flag.Var:
+//
+//	level := logger.InfoLevel
+//	flag.Var(&level, "log-level", "minimum log level")
+func (l *Level) Set(s string) error {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. Levels are encoded by name,
+// in JSON as strings.
+func (l Level) MarshalText() ([]byte, error) {
+	if _, ok := lookupLevel(l); !ok && l != NoLevel {
+		return nil, fmt.Errorf("invalid log level: %d", l)
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting what ParseLevel accepts
+func (l *Level) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+// Severity returns the rank of the level: entries are written if the severity
+// of their level is at least the severity of the logger level. The severity of
+// trace is -10, debug 0, info 10, notice 15, warn 20, error 30, critical 35,
+// fatal 40 and panic 50; custom levels have the severity they were registered with.
+func (l Level) Severity() int {
+	if info, ok := lookupLevel(l); ok {
+		return info.severity
+	}
+	return int(l) * 10
+}
+
+// SyslogSeverity returns the RFC 5424 severity of the level, from 0
+// (emergency) to 7 (debug): panic is 0, fatal 1, critical 2, error 3, warn 4,
+// notice 5, info 6, and debug and trace 7. Custom levels get the severity of
+// the closest lower built-in level.
+func (l Level) SyslogSeverity() int {
+	severity := l.Severity()
+	for i, level := range []Level{PanicLevel, FatalLevel, CriticalLevel, ErrorLevel, WarnLevel, NoticeLevel, InfoLevel} {
+		if severity >= level.Severity() {
+			return i
+		}
+	}
+	return 7
+}
+
+// severityHook adds the syslog severity to the entries at zerolog levels
+type severityHook struct{}
+
+// Run implements zerolog.Hook
+func (severityHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if level != zerolog.NoLevel {
+		e.Int(SeverityFieldName, Level(level).SyslogSeverity())
+	}
+}
+
+// native reports whether the level is a zerolog level
+func (l Level) native() bool {
+	info, ok := lookupLevel(l)
+	return !ok || Level(info.zlevel) == l
+}
+
+// zerologLevel returns the zerolog level the entries of the level are written at
+func (l Level) zerologLevel() zerolog.Level {
+	if info, ok := lookupLevel(l); ok {
+		return info.zlevel
+	}
+	return zerolog.Level(l)
+}
+
+// filterLevel returns the zerolog level of a logger writing the entries at
+// level l or above: the lowest zerolog level with at least the same severity
+func (l Level) filterLevel() zerolog.Level {
+	if l.native() {
+		return zerolog.Level(l)
+	}
+	for z := zerolog.TraceLevel; z <= zerolog.PanicLevel; z++ {
+		if Level(z).Severity() >= l.Severity() {
+			return z
+		}
+	}
+	return zerolog.NoLevel
+}
+
+// formatLevel returns the level formatter of the pretty output, which knows
+// the levels that zerolog does not, styled with the palette and prefixed with
+// the icon of the level, if any
+func formatLevel(noColor bool, palette Palette, icons map[Level]string) zerolog.Formatter {
+	return func(i any) string {
+		s, ok := i.(string)
+		if !ok {
+			if i == nil {
+				return "???"
+			}
+			s = fmt.Sprint(i)
+		}
+		level, err := ParseLevel(s)
+		if err != nil {
+			if len(s) > 3 {
+				s = s[:3]
+			}
+			return strings.ToUpper(s)
+		}
+		info, _ := lookupLevel(level)
+		formatted := info.formatted
+		if icon, ok := icons[level]; ok && icon != "" {
+			formatted = icon + " " + formatted
+		}
+		style := palette.style(level, info)
+		if noColor || style == "" {
+			return formatted
+		}
+		return fmt.Sprintf("\x1b[%sm%s\x1b[0m", style, formatted)
+	}
+}
@@ -2,17 +2,75 @@
 package logger
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 )
 
+// Default keys used for the standard fields when none are configured.
+const (
+	DefaultTimestampFieldName = "time"
+	DefaultLevelFieldName     = "level"
+	DefaultMessageFieldName   = "message"
+	DefaultCallerFieldName    = "caller"
+	DefaultServiceFieldName   = "service"
+)
+
+// Keys used when expanding wrapped error chains.
+const (
+	ErrorChainFieldName = "error_chain"
+	ErrorRootFieldName  = "error_root"
+	ErrorCodeFieldName  = "error_code"
+)
+
+// CallerFuncFieldName is the key of the caller function added with Config.CallerFunc
+const CallerFuncFieldName = "caller_func"
+
+// Special TimeFormat values that render the timestamp as a numeric epoch.
+const (
+	// TimeFormatUnix renders the timestamp as seconds since the Unix epoch
+	TimeFormatUnix = "unix"
+	// TimeFormatUnixMs renders the timestamp as milliseconds since the Unix epoch
+	TimeFormatUnixMs = "unix_ms"
+)
+
+// Duration rendering formats for Config.DurationFormat.
+const (
+	// DurationFormatMs renders durations as fractional milliseconds (default)
+	DurationFormatMs = "ms"
+	// DurationFormatSeconds renders durations as fractional seconds
+	DurationFormatSeconds = "s"
+	// DurationFormatString renders durations as strings such as "1.5s"
+	DurationFormatString = "string"
+)
+
 // Logger wraps zerolog.Logger to provide additional functionality.
 type Logger struct {
-	zl          zerolog.Logger
-	serviceName string
+	cfg            Config
+	zl             zerolog.Logger
+	base           zerolog.Logger
+	fields         []contextField
+	keyCollision   KeyCollisionPolicy
+	writer         io.Writer
+	serviceName    string
+	durationFormat string
+	errorChain     bool
+	prefix         string
+	stackTrace     bool
+	errMarshalers  []ErrorMarshaler
+	withCaller     bool
+	callerFunc     bool
+	callerTrim     string
+	relativeCaller bool
+	clock          func() time.Time
+	tenants        *tenantWriters
 }
 
 // LogBuilder provides a fluid interface for creating logs with formatted messages.
@@ -20,22 +78,155 @@ type LogBuilder struct {
 	logger *Logger
 	event  *zerolog.Event
 	err    error
+	stack  bool
+	caller bool
+	// callerSkip is the number of frames outside the logger skipped to find the caller
+	callerSkip int
+	prefix     string
+	fatal      bool
+	// exitCode is the process exit code of a Fatal log
+	exitCode int
+	// capture records the entry of a Panic log to build the panic value
+	capture *entryCapture
+}
+
+// logBuilderPool reuses log builders between entries, like zerolog does for events
+var logBuilderPool = sync.Pool{
+	New: func() any {
+		return &LogBuilder{}
+	},
 }
 
-// Config contains configuration options for the logger.
+// Config contains configuration options for the logger. It can be embedded in
+// application config structs and decoded from JSON, YAML or the environment:
+// levels and enums are decoded from their names, such as "warn". Fields that
+// cannot be serialized, such as Output and hooks, or that hold secrets, such as
+// SigningKey, are ignored and must be set in code.
 type Config struct {
 	// Level sets the minimum level of log messages to output
-	Level Level
+	Level Level `json:"level" yaml:"level" env:"LOG_LEVEL"`
 	// Pretty enables pretty, more human-readable logging format
-	Pretty bool
+	Pretty bool `json:"pretty" yaml:"pretty"`
+	// Format sets the output format, taking precedence over Pretty. Defaults
+	// to FormatPretty if Pretty is enabled, FormatJSON otherwise
+	Format Format `json:"format" yaml:"format" env:"LOG_FORMAT"`
+	// Palette sets the level colors of FormatPretty, such as
+	// PaletteDeuteranopia. Defaults to PaletteDefault if empty
+	Palette Palette `json:"palette" yaml:"palette" env:"LOG_PALETTE"`
+	// LevelIcons prefixes the level of FormatPretty entries with a glyph or
+	// string per level, such as DefaultLevelIcons
+	LevelIcons map[Level]string `json:"level_icons" yaml:"level_icons"`
+	// CSVColumns lists the fields written by FormatCSV, in order. Defaults to
+	// the timestamp, level, service and message fields
+	CSVColumns []string `json:"csv_columns" yaml:"csv_columns"`
+	// CSVHeader writes the CSV column names before the first entry
+	CSVHeader bool `json:"csv_header" yaml:"csv_header"`
 	// WithCaller adds the caller information (file and line) to log entries
-	WithCaller bool
+	WithCaller bool `json:"with_caller" yaml:"with_caller" env:"LOG_CALLER"`
+	// CallerFunc adds the function of the caller, as pkg.Func, in the
+	// "caller_func" field, which stays useful in binaries built with -trimpath
+	CallerFunc bool `json:"caller_func" yaml:"caller_func"`
+	// CallerTrimPrefix is removed, with everything before it, from the caller
+	// paths containing it, so callers read internal/api/handler.go:42. Defaults
+	// to the main module path, which prefixes the paths of -trimpath builds.
+	// Other builds have absolute paths, where the module root directory, found
+	// from the go.mod declaring the prefix, is removed instead
+	CallerTrimPrefix string `json:"caller_trim_prefix" yaml:"caller_trim_prefix"`
 	// Output is where log entries will be written. Defaults to os.Stderr if nil
-	Output io.Writer
-	// TimeFormat specifies the format for timestamps
-	TimeFormat string
+	Output io.Writer `json:"-" yaml:"-"`
+	// TimeFormat specifies the format for timestamps. Use TimeFormatUnix or
+	// TimeFormatUnixMs for numeric epoch output
+	TimeFormat string `json:"time_format" yaml:"time_format" env:"LOG_TIME_FORMAT"`
+	// DisableTimestamp omits the timestamp field from log entries
+	DisableTimestamp bool `json:"disable_timestamp" yaml:"disable_timestamp"`
+	// TimestampFieldName is the key used for the timestamp. Defaults to "time" if empty
+	TimestampFieldName string `json:"timestamp_field_name" yaml:"timestamp_field_name"`
+	// LevelFieldName is the key used for the level. Defaults to "level" if empty
+	LevelFieldName string `json:"level_field_name" yaml:"level_field_name"`
+	// MessageFieldName is the key used for the message. Defaults to "message" if empty
+	MessageFieldName string `json:"message_field_name" yaml:"message_field_name"`
+	// CallerFieldName is the key used for the caller. Defaults to "caller" if empty
+	CallerFieldName string `json:"caller_field_name" yaml:"caller_field_name"`
+	// ServiceFieldName is the key used for the service name. Defaults to "service" if empty
+	ServiceFieldName string `json:"service_field_name" yaml:"service_field_name"`
 	// ServiceName identifies the service that generated the log
-	ServiceName string
+	ServiceName string `json:"service_name" yaml:"service_name" env:"SERVICE_NAME"`
+	// DurationFormat sets how durations are rendered. Defaults to DurationFormatMs if empty
+	DurationFormat string `json:"duration_format" yaml:"duration_format"`
+	// ErrorChain expands wrapped errors into an error chain array and a root cause field
+	ErrorChain bool `json:"error_chain" yaml:"error_chain"`
+	// StackTrace adds a stack trace to error, fatal and panic level entries
+	StackTrace bool `json:"stack_trace" yaml:"stack_trace"`
+	// ErrorMarshalers add structured fields for errors passed to WithError
+	ErrorMarshalers []ErrorMarshaler `json:"-" yaml:"-"`
+	// RedactKeys lists field keys whose values are replaced by RedactedValue.
+	// Keys are matched case-insensitively at any nesting level
+	RedactKeys []string `json:"redact_keys" yaml:"redact_keys"`
+	// Scrubbers mask text matching regular expressions in the message and string fields
+	Scrubbers []Scrubber `json:"-" yaml:"-"`
+	// FieldAllowlist, if not empty, drops every field not listed. The standard
+	// fields are always kept
+	FieldAllowlist []string `json:"field_allowlist" yaml:"field_allowlist"`
+	// FieldBlocklist drops the listed fields
+	FieldBlocklist []string `json:"field_blocklist" yaml:"field_blocklist"`
+	// PseudonymizeKeys lists field keys whose values are replaced by an HMAC-SHA256
+	// hash keyed with PseudonymizeSecret
+	PseudonymizeKeys []string `json:"pseudonymize_keys" yaml:"pseudonymize_keys"`
+	// PseudonymizeSecret is the HMAC key used for PseudonymizeKeys
+	PseudonymizeSecret []byte `json:"-" yaml:"-"`
+	// DetectSecrets masks likely secrets (AWS keys, JWTs, private keys) and
+	// writes a warning identifying the call site
+	DetectSecrets bool `json:"detect_secrets" yaml:"detect_secrets"`
+	// MaxFields caps the number of fields per entry, not counting the standard
+	// fields. Dropped fields are counted in the "_extra_fields" field. Zero means no limit
+	MaxFields int `json:"max_fields" yaml:"max_fields"`
+	// Schema, if set, validates every entry and annotates or rejects the ones not conforming
+	Schema *Schema `json:"-" yaml:"-"`
+	// Clock returns the time used for timestamps and progress durations.
+	// Defaults to time.Now if nil
+	Clock func() time.Time `json:"-" yaml:"-"`
+	// Deterministic makes the output byte-stable across runs and machines: the
+	// timestamp is fixed to DeterministicTime unless Clock is set, caller paths
+	// are relative to the working directory and keys are sorted
+	Deterministic bool `json:"deterministic" yaml:"deterministic"`
+	// GoroutineID adds the ID of the logging goroutine to every entry, to untangle
+	// interleaved concurrent logs. Meant for debugging only: the ID is parsed from
+	// runtime.Stack, which costs about a microsecond and an allocation per entry
+	GoroutineID bool `json:"goroutine_id" yaml:"goroutine_id"`
+	// KeyCollision decides how WithFields handles keys already in the logger
+	// context. Defaults to KeyCollisionOverwrite
+	KeyCollision KeyCollisionPolicy `json:"key_collision" yaml:"key_collision"`
+	// ExitFunc terminates the process after a fatal entry. Defaults to os.Exit
+	ExitFunc func(code int) `json:"-" yaml:"-"`
+	// FatalHook is called after a fatal entry is written and before the
+	// process exits, to flush or close sinks
+	FatalHook func() `json:"-" yaml:"-"`
+	// HealthChecks are the sinks reported by Health, besides the output
+	HealthChecks map[string]HealthChecker `json:"-" yaml:"-"`
+	// SigningKey, if set, appends to every entry an HMAC signature chained to
+	// the previous entry, so the log can be checked with Verify. Ignored with
+	// formats other than FormatJSON
+	SigningKey []byte `json:"-" yaml:"-"`
+	// ProgressInterval is the minimum time between the entries of a Progress.
+	// Defaults to DefaultProgressInterval if zero
+	ProgressInterval time.Duration `json:"progress_interval" yaml:"progress_interval"`
+	// ErrorClassifiers choose the level of the errors logged with ErrOr. The
+	// first classifier recognizing an error wins
+	ErrorClassifiers []ErrorClassifier `json:"-" yaml:"-"`
+	// ErrorCodes maps errors, matched with errors.Is, to the code added in the
+	// "error_code" field by WithError. Errors implementing Coder take precedence
+	ErrorCodes map[error]string `json:"-" yaml:"-"`
+	// SyslogSeverity adds the RFC 5424 numeric severity of the level in the
+	// "severity" field, as required by some syslog and SIEM ingestion pipelines
+	SyslogSeverity bool `json:"syslog_severity" yaml:"syslog_severity"`
+	// TenantOutput returns the output of the loggers created with ForTenant, or
+	// nil for a tenant that writes to the shared output
+	TenantOutput func(tenant string) io.Writer `json:"-" yaml:"-"`
+	// Quota caps the entries per minute for each value of the QuotaKey field.
+	// Suppressed entries are counted in a summary once the minute is over. Zero means no limit
+	Quota int `json:"quota" yaml:"quota"`
+	// QuotaKey is the field the Quota applies to. Defaults to TenantFieldName if empty
+	QuotaKey string `json:"quota_key" yaml:"quota_key"`
 }
 
 // DefaultConfig returns a default configuration for the logger.
@@ -62,35 +253,149 @@ func New(cfg Config) *Logger {
 		serviceName = "UNKNOWN-SERVICE"
 	}
 
-	zctx := zerolog.New(output).
-		Level(zerolog.Level(cfg.Level)).
-		With()
+	serviceKey := fieldName(cfg.ServiceFieldName, DefaultServiceFieldName)
+	writer := newWriter(cfg, output, serviceName)
 
-	zctx = zctx.Timestamp()
+	zctx := zerolog.New(writer).
+		Level(cfg.Level.filterLevel()).
+		With()
 
-	zctx = zctx.Str("service", serviceName)
+	clock := loggerClock(cfg)
+	base := zctx.Logger()
+	if !cfg.DisableTimestamp {
+		base = base.Hook(clockHook{clock: clock, format: cfg.TimeFormat})
+	}
+	if cfg.GoroutineID {
+		base = base.Hook(goroutineHook{})
+	}
+	if cfg.SyslogSeverity {
+		base = base.Hook(severityHook{})
+	}
 
-	if cfg.WithCaller {
-		zctx = zctx.Caller()
+	l := &Logger{
+		cfg:            cfg,
+		base:           base,
+		keyCollision:   cfg.KeyCollision,
+		writer:         writer,
+		serviceName:    serviceName,
+		durationFormat: cfg.DurationFormat,
+		errorChain:     cfg.ErrorChain,
+		stackTrace:     cfg.StackTrace,
+		errMarshalers:  cfg.ErrorMarshalers,
+		withCaller:     cfg.WithCaller,
+		callerFunc:     cfg.CallerFunc,
+		callerTrim:     callerTrimPrefix(cfg.CallerTrimPrefix),
+		relativeCaller: cfg.Deterministic,
+		clock:          clock,
+	}
+	if cfg.TenantOutput != nil {
+		l.tenants = &tenantWriters{writers: make(map[string]tenantWriter)}
 	}
+	return l.withContext([]contextField{{key: serviceKey, value: serviceName}})
+}
 
-	var zl zerolog.Logger
-	if cfg.Pretty {
+// newWriter builds the writer chain of a logger writing to output: the writer
+// of the format or the signature chain, then the entry processors, starting
+// with the renaming of the standard fields
+func newWriter(cfg Config, output io.Writer, serviceName string) io.Writer {
+	writer := output
+	switch cfg.format() {
+	case FormatPretty:
 		consoleWriter := zerolog.ConsoleWriter{
-			Out:        output,
-			TimeFormat: cfg.TimeFormat,
+			Out:         output,
+			TimeFormat:  cfg.TimeFormat,
+			FormatLevel: formatLevel(false, cfg.Palette, cfg.LevelIcons),
+		}
+		if isUnixTimeFormat(cfg.TimeFormat) {
+			// Numeric timestamps are rendered with the console default format
+			consoleWriter.TimeFormat = ""
+			consoleWriter.FormatTimestamp = formatUnixTimestamp(cfg.TimeFormat)
+		}
+		writer = consoleWriter
+	case FormatJSONPretty:
+		writer = IndentedJSONFormatter{}.Format(output)
+	case FormatCSV:
+		columns := cfg.CSVColumns
+		if len(columns) == 0 {
+			columns = []string{
+				fieldName(cfg.TimestampFieldName, DefaultTimestampFieldName),
+				fieldName(cfg.LevelFieldName, DefaultLevelFieldName),
+				fieldName(cfg.ServiceFieldName, DefaultServiceFieldName),
+				fieldName(cfg.MessageFieldName, DefaultMessageFieldName),
+			}
+		}
+		writer = CSVFormatter{Columns: columns, Header: cfg.CSVHeader}.Format(output)
+	case FormatLTSV:
+		writer = LTSVFormatter{}.Format(output)
+	case FormatPlain:
+		writer = PlainFormatter{}.Format(output)
+	case FormatGitHub:
+		writer = GitHubActionsFormatter{}.Format(output)
+	default:
+		if len(cfg.SigningKey) > 0 {
+			writer = newSignWriter(writer, cfg.SigningKey)
+		}
+	}
+	var processors []entryProcessor
+	if names := renamedFields(cfg); len(names) > 0 {
+		processors = append(processors, renameFields(names))
+		switch cfg.format() {
+		case FormatPretty, FormatPlain, FormatGitHub:
+			// These formats find the standard fields by zerolog's keys
+			writer = zerologKeysWriter(cfg, writer)
 		}
-		zl = zctx.Logger().Output(consoleWriter)
-	} else {
-		zl = zctx.Logger()
 	}
+	serviceKey := fieldName(cfg.ServiceFieldName, DefaultServiceFieldName)
+	meta := zerolog.New(newProcessWriter(writer, processors)).With().Str(serviceKey, serviceName).Logger().
+		Hook(clockHook{clock: loggerClock(cfg), format: cfg.TimeFormat})
+	// The chain always starts with a processWriter, which loggers derived with
+	// WithObserver share
+	return &processWriter{out: writer, processors: append(processors, entryProcessors(cfg, meta)...)}
+}
 
-	zerolog.TimeFieldFormat = cfg.TimeFormat
+// zerologKeysWriter wraps w to rename the standard fields configured in cfg
+// back to zerolog's keys
+func zerologKeysWriter(cfg Config, w io.Writer) io.Writer {
+	names := renamedFields(cfg)
+	if len(names) == 0 {
+		return w
+	}
+	restore := make(map[string]string, len(names))
+	for key, name := range names {
+		restore[name] = key
+	}
+	return newProcessWriter(w, []entryProcessor{renameFields(restore)})
+}
+
+// isUnixTimeFormat reports whether the format renders a numeric epoch
+func isUnixTimeFormat(format string) bool {
+	return format == TimeFormatUnix || format == TimeFormatUnixMs
+}
+
+// renamedFields maps zerolog's keys of the standard fields to the keys
+// configured in cfg, for the ones that differ. The keys are renamed by each
+// logger rather than set in zerolog's globals, shared by every logger.
+func renamedFields(cfg Config) map[string]string {
+	names := make(map[string]string)
+	for key, name := range map[string]string{
+		zerolog.TimestampFieldName: cfg.TimestampFieldName,
+		zerolog.LevelFieldName:     cfg.LevelFieldName,
+		zerolog.MessageFieldName:   cfg.MessageFieldName,
+		zerolog.CallerFieldName:    cfg.CallerFieldName,
+	} {
+		if name != "" && name != key {
+			names[key] = name
+		}
+	}
+	return names
+}
 
-	return &Logger{
-		zl:          zl,
-		serviceName: serviceName,
+// fieldName returns name, or fallback if name is empty
+func fieldName(name, fallback string) string {
+	if name == "" {
+		return fallback
 	}
+	return name
 }
 
 // ServiceName returns the name of the service used by this logger
@@ -101,70 +406,408 @@ func (l *Logger) ServiceName() string {
 // SetServiceName sets the name of the service used by this logger
 func (l *Logger) SetServiceName(name string) {
 	l.serviceName = name
+	l.cfg.ServiceName = name
 }
 
-// With adds key-value pairs to the logger context
-func (l *Logger) With() zerolog.Context {
-	return l.zl.With()
+// With starts a ContextBuilder to create a child logger with typed context fields
+func (l *Logger) With() *ContextBuilder {
+	return &ContextBuilder{logger: l}
 }
 
 // WithFields returns a new logger with the given fields added to the context.
+// Fields are added in key order; keys already in the context are handled
+// according to the KeyCollision policy. With the default policy the new value
+// always replaces the inherited one; use Without first to replace a field
+// regardless of the policy.
 func (l *Logger) WithFields(fields map[string]any) *Logger {
-	ctx := l.zl.With()
-	for k, v := range fields {
-		ctx = ctx.Interface(k, v)
-	}
-	return &Logger{
-		zl:          ctx.Logger(),
-		serviceName: l.serviceName,
-	}
+	return l.withContext(mergeFields(l.fields, sortedFields(fields, l.prefix), l.keyCollision))
+}
+
+// Without returns a new logger with the given context fields removed. Keys are
+// matched as written in the output, including any prefix.
+func (l *Logger) Without(keys ...string) *Logger {
+	return l.withContext(removeFields(l.fields, keys))
+}
+
+// WithPrefix returns a new logger that prefixes the keys of the fields added
+// to it and its entries, such as "db." for "db.query". Prefixes of nested
+// child loggers are concatenated; existing context fields are not renamed.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	child := *l
+	child.prefix = l.prefix + prefix
+	return &child
+}
+
+// CloneWith returns a new logger created from this logger's configuration as
+// modified by fn, keeping its context fields and prefix. The service field
+// follows the new configuration, and observers are not carried over. For
+// example, to write a one-off report to a file:
+//
+//	report := log.CloneWith(func(c *logger.Config) {
+//		c.Output = f
+//		c.Pretty = false
+//	})
+func (l *Logger) CloneWith(fn func(*Config)) *Logger {
+	cfg := l.cfg
+	fn(&cfg)
+	clone := New(cfg)
+	clone.prefix = l.prefix
+	fields := removeFields(l.fields, []string{fieldName(l.cfg.ServiceFieldName, DefaultServiceFieldName)})
+	return clone.withContext(mergeFields(clone.fields, fields, KeyCollisionKeepFirst))
 }
 
 // SetLevel changes the log level of the logger
 func (l *Logger) SetLevel(level Level) {
-	l.zl = l.zl.Level(zerolog.Level(level))
+	l.cfg.Level = level
+	l.setBase(l.base.Level(level.filterLevel()))
 }
 
-// NewLogBuilder creates a new log builder instance
+// NewLogBuilder creates a new log builder instance. It returns nil, a no-op
+// builder, if the event is disabled.
 func (l *Logger) newLogBuilder(event *zerolog.Event) *LogBuilder {
-	return &LogBuilder{
-		logger: l,
-		event:  event,
+	if event == nil {
+		return nil
+	}
+	lb := logBuilderPool.Get().(*LogBuilder)
+	lb.logger = l
+	lb.event = event
+	lb.prefix = l.prefix
+	return lb
+}
+
+// key returns the field key with the logger prefix
+func (lb *LogBuilder) key(key string) string {
+	return lb.prefix + key
+}
+
+// release resets the log builder and returns it to the pool. The builder
+// must not be used afterwards.
+func (lb *LogBuilder) release() {
+	*lb = LogBuilder{}
+	logBuilderPool.Put(lb)
+}
+
+// newErrorLogBuilder creates a log builder for error and higher levels,
+// adding a stack trace if enabled
+func (l *Logger) newErrorLogBuilder(event *zerolog.Event) *LogBuilder {
+	lb := l.newLogBuilder(event)
+	if lb != nil {
+		lb.stack = l.stackTrace
 	}
+	return lb
 }
 
 // WithError adds an error to the log builder
 func (lb *LogBuilder) WithError(err error) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.err = err
 	lb.event.Err(err)
+	if lb.logger.errorChain && err != nil && errors.Unwrap(err) != nil {
+		chain := []string{}
+		root := err
+		for e := err; e != nil; e = errors.Unwrap(e) {
+			chain = append(chain, e.Error())
+			root = e
+		}
+		lb.event.Strs(ErrorChainFieldName, chain).Str(ErrorRootFieldName, root.Error())
+	}
+	if err != nil {
+		if code := lb.logger.ErrorCode(err); code != "" {
+			lb.event.Str(ErrorCodeFieldName, code)
+		}
+		for _, marshal := range lb.logger.errMarshalers {
+			marshal(lb, err)
+		}
+	}
+	return lb
+}
+
+// Errs adds a list of errors to the log as an array of error strings
+func (lb *LogBuilder) Errs(key string, errs []error) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Errs(lb.key(key), errs)
 	return lb
 }
 
 // Field adds a generic field to the log
 func (lb *LogBuilder) AddField(key string, value any) *LogBuilder {
-	lb.event.Interface(key, value)
+	if lb == nil {
+		return lb
+	}
+	lb.event.Interface(lb.key(key), marshalValue(value))
 	return lb
 }
 
+// LazyField adds a generic field whose value is computed by fn. fn is only
+// called if the log is enabled and will actually be written.
+func (lb *LogBuilder) LazyField(key string, fn func() any) *LogBuilder {
+	if !lb.Enabled() {
+		return lb
+	}
+	return lb.AddField(key, fn())
+}
+
 // Str adds a string field to the log
 func (lb *LogBuilder) Str(key string, value string) *LogBuilder {
-	lb.event.Str(key, value)
+	if lb == nil {
+		return lb
+	}
+	lb.event.Str(lb.key(key), value)
 	return lb
 }
 
 // Int adds an integer field to the log
 func (lb *LogBuilder) Int(key string, value int) *LogBuilder {
-	lb.event.Int(key, value)
+	if lb == nil {
+		return lb
+	}
+	lb.event.Int(lb.key(key), value)
+	return lb
+}
+
+// Int8 adds an int8 field to the log
+func (lb *LogBuilder) Int8(key string, value int8) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Int8(lb.key(key), value)
+	return lb
+}
+
+// Int16 adds an int16 field to the log
+func (lb *LogBuilder) Int16(key string, value int16) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Int16(lb.key(key), value)
+	return lb
+}
+
+// Int32 adds an int32 field to the log
+func (lb *LogBuilder) Int32(key string, value int32) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Int32(lb.key(key), value)
+	return lb
+}
+
+// Int64 adds an int64 field to the log
+func (lb *LogBuilder) Int64(key string, value int64) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Int64(lb.key(key), value)
+	return lb
+}
+
+// Uint adds an unsigned integer field to the log
+func (lb *LogBuilder) Uint(key string, value uint) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Uint(lb.key(key), value)
+	return lb
+}
+
+// Uint8 adds a uint8 field to the log
+func (lb *LogBuilder) Uint8(key string, value uint8) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Uint8(lb.key(key), value)
+	return lb
+}
+
+// Uint16 adds a uint16 field to the log
+func (lb *LogBuilder) Uint16(key string, value uint16) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Uint16(lb.key(key), value)
+	return lb
+}
+
+// Uint32 adds a uint32 field to the log
+func (lb *LogBuilder) Uint32(key string, value uint32) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Uint32(lb.key(key), value)
+	return lb
+}
+
+// Uint64 adds a uint64 field to the log
+func (lb *LogBuilder) Uint64(key string, value uint64) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Uint64(lb.key(key), value)
 	return lb
 }
 
 // Bool adds a boolean field to the log
 func (lb *LogBuilder) Bool(key string, value bool) *LogBuilder {
-	lb.event.Bool(key, value)
+	if lb == nil {
+		return lb
+	}
+	lb.event.Bool(lb.key(key), value)
+	return lb
+}
+
+// RawJSON adds a field containing pre-serialized JSON to the log without
+// escaping it. Invalid JSON is added as a string instead, so the entry stays
+// valid JSON.
+func (lb *LogBuilder) RawJSON(key string, b []byte) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	if lb.event != nil && !json.Valid(b) {
+		lb.event.Str(lb.key(key), string(b))
+		return lb
+	}
+	lb.event.RawJSON(lb.key(key), b)
+	return lb
+}
+
+// Dict adds a nested object field to the log. The fields added to the
+// builder passed to fn are written inside the object.
+func (lb *LogBuilder) Dict(key string, fn func(*LogBuilder)) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	dict := lb.logger.newLogBuilder(zerolog.Dict())
+	dict.prefix = ""
+	fn(dict)
+	lb.event.Dict(lb.key(key), dict.event)
+	dict.release()
+	return lb
+}
+
+// Float64 adds a float64 field to the log
+func (lb *LogBuilder) Float64(key string, value float64) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Float64(lb.key(key), value)
+	return lb
+}
+
+// Float32 adds a float32 field to the log
+func (lb *LogBuilder) Float32(key string, value float32) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Float32(lb.key(key), value)
+	return lb
+}
+
+// IPAddr adds an IP address field to the log
+func (lb *LogBuilder) IPAddr(key string, ip net.IP) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.IPAddr(lb.key(key), ip)
+	return lb
+}
+
+// IPPrefix adds an IP network prefix (CIDR) field to the log
+func (lb *LogBuilder) IPPrefix(key string, prefix net.IPNet) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.IPPrefix(lb.key(key), prefix)
 	return lb
 }
 
+// MACAddr adds a hardware (MAC) address field to the log
+func (lb *LogBuilder) MACAddr(key string, addr net.HardwareAddr) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.MACAddr(lb.key(key), addr)
+	return lb
+}
+
+// Dur adds a duration field to the log, rendered according to the configured DurationFormat
+func (lb *LogBuilder) Dur(key string, d time.Duration) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	switch lb.logger.durationFormat {
+	case DurationFormatSeconds:
+		lb.event.Float64(lb.key(key), d.Seconds())
+	case DurationFormatString:
+		lb.event.Str(lb.key(key), d.String())
+	default:
+		lb.event.Float64(lb.key(key), float64(d)/float64(time.Millisecond))
+	}
+	return lb
+}
+
+// TimeDiff adds the time elapsed since the given start time, measured with the
+// logger clock, to the log
+func (lb *LogBuilder) TimeDiff(key string, since time.Time) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	return lb.Dur(key, lb.logger.clock().Sub(since))
+}
+
+// Enabled reports whether the logger writes entries at the given level.
+// Use it to guard expensive field computation.
+func (l *Logger) Enabled(level Level) bool {
+	if !debugEnabled && level.Severity() <= DebugLevel.Severity() {
+		return false
+	}
+	zlevel := level.zerologLevel()
+	if zerolog.GlobalLevel() > zlevel {
+		return false
+	}
+	if level.native() {
+		return l.zl.GetLevel() <= zlevel
+	}
+	return l.zl.GetLevel() != zerolog.Disabled && level.Severity() >= l.cfg.Level.Severity()
+}
+
+// customLog creates a log at a level zerolog does not know, with a stack
+// trace if enabled for levels at least as severe as error
+func (l *Logger) customLog(level Level) *LogBuilder {
+	event := l.levelEvent(level)
+	if level.Severity() >= ErrorLevel.Severity() {
+		return l.newErrorLogBuilder(event)
+	}
+	return l.newLogBuilder(event)
+}
+
+// levelEvent creates an event at a level zerolog does not know. The event has
+// no zerolog level, so the level is filtered here and its field added by hand.
+func (l *Logger) levelEvent(level Level) *zerolog.Event {
+	if !l.Enabled(level) {
+		return nil
+	}
+	event := l.zl.Log().Str(zerolog.LevelFieldName, level.String())
+	if l.cfg.SyslogSeverity {
+		event.Int(SeverityFieldName, level.SyslogSeverity())
+	}
+	return event
+}
+
+// Enabled reports whether the log will be written. Disabled builders are nil
+// and all their methods are no-ops.
+func (lb *LogBuilder) Enabled() bool {
+	return lb != nil && lb.event.Enabled()
+}
+
 // Debug creates a debug level log
 func (l *Logger) Debug() *LogBuilder {
+	if !debugEnabled {
+		return nil
+	}
 	return l.newLogBuilder(l.zl.Debug())
 }
 
@@ -173,6 +816,11 @@ func (l *Logger) Info() *LogBuilder {
 	return l.newLogBuilder(l.zl.Info())
 }
 
+// Notice creates a notice level log, for normal but significant events
+func (l *Logger) Notice() *LogBuilder {
+	return l.customLog(NoticeLevel)
+}
+
 // Warn creates a warn level log
 func (l *Logger) Warn() *LogBuilder {
 	return l.newLogBuilder(l.zl.Warn())
@@ -180,60 +828,213 @@ func (l *Logger) Warn() *LogBuilder {
 
 // Error creates an error level log
 func (l *Logger) Error() *LogBuilder {
-	return l.newLogBuilder(l.zl.Error())
+	return l.newErrorLogBuilder(l.zl.Error())
 }
 
-// Fatal creates a fatal level log
+// Critical creates a critical level log, for failures that need immediate
+// attention but do not end the process
+func (l *Logger) Critical() *LogBuilder {
+	return l.customLog(CriticalLevel)
+}
+
+// Fatal creates a fatal level log. The process exits after Msg; as with
+// zerolog, it exits immediately if the fatal level is disabled.
 func (l *Logger) Fatal() *LogBuilder {
-	return l.newLogBuilder(l.zl.Fatal())
+	lb := l.newErrorLogBuilder(l.zl.WithLevel(zerolog.FatalLevel))
+	if lb == nil {
+		l.exit(1)
+		return nil
+	}
+	lb.fatal = true
+	lb.exitCode = 1
+	return lb
+}
+
+// ExitCode sets the code the process exits with after a Fatal log, 1 by
+// default. It has no effect on other levels.
+func (lb *LogBuilder) ExitCode(code int) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.exitCode = code
+	return lb
 }
 
-// Panic creates a panic level log
+// Panic creates a panic level log. Msg flushes the output and panics with a
+// *PanicError carrying the message and fields of the entry; as with zerolog,
+// it panics immediately if the panic level is disabled.
 func (l *Logger) Panic() *LogBuilder {
-	return l.newLogBuilder(l.zl.Panic())
+	lb := l.newErrorLogBuilder(l.zl.WithLevel(zerolog.PanicLevel))
+	if lb == nil {
+		panic("")
+	}
+	lb.capture = l.writer.(*processWriter).capture(lb.event)
+	return lb
 }
 
 // Trace creates a trace level log
 func (l *Logger) Trace() *LogBuilder {
+	if !debugEnabled {
+		return nil
+	}
 	return l.newLogBuilder(l.zl.Trace())
 }
 
-// Msg finalizes the log with a message
+// Log creates a log without level, for raw structured events such as metrics
+// or audit records. It is written whatever the logger level, unless the
+// logger is disabled.
+func (l *Logger) Log() *LogBuilder {
+	return l.newLogBuilder(l.zl.Log())
+}
+
+// WithLevel creates a log at the given level, such as a custom level
+// registered with RegisterLevel. Fatal and panic levels end the process or
+// panic like Fatal and Panic.
+func (l *Logger) WithLevel(level Level) *LogBuilder {
+	switch level {
+	case FatalLevel:
+		return l.Fatal()
+	case PanicLevel:
+		return l.Panic()
+	}
+	return l.logAt(level)
+}
+
+// When disables the log if cond is false, turning the remaining calls into no-ops
+func (lb *LogBuilder) When(cond bool) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	if !cond {
+		lb.event = lb.event.Discard()
+	}
+	return lb
+}
+
+// Msg finalizes the log with a message. The message is written as is unless
+// values are given, in which case it is used as a format string like Msgf.
+// The builder must not be used after calling Msg.
 func (lb *LogBuilder) Msg(msg string, values ...any) {
-	lb.event.Msgf(msg, values...)
+	if lb == nil {
+		return
+	}
+	lb.send(msg, values)
+}
+
+// Msgf finalizes the log with a message formatted with fmt.Sprintf
+func (lb *LogBuilder) Msgf(format string, values ...any) {
+	if lb == nil {
+		return
+	}
+	lb.send(fmt.Sprintf(format, values...), nil)
+}
+
+// MsgFunc finalizes the log with the message returned by fn. fn is only
+// called if the log is enabled and will actually be written.
+func (lb *LogBuilder) MsgFunc(fn func() string) {
+	if lb == nil {
+		return
+	}
+	if !lb.event.Enabled() {
+		lb.release()
+		return
+	}
+	lb.send(fn(), nil)
+}
+
+// send adds the final fields and writes the log. The values are passed as a
+// slice so that Msg is not treated as a printf wrapper by go vet.
+func (lb *LogBuilder) send(msg string, values []any) {
+	if len(values) > 0 {
+		msg = fmt.Sprintf(msg, values...)
+	}
+	if lb.logger.withCaller || lb.caller {
+		lb.addCaller()
+	}
+	if lb.stack {
+		lb.addStack()
+	}
+	lb.event.Msg(msg)
+	l, err, capture := lb.logger, lb.err, lb.capture
+	fatal, exitCode := lb.fatal && lb.event != nil, lb.exitCode
+	lb.release()
+	if fatal {
+		l.exit(exitCode)
+	}
+	if capture != nil {
+		capture.release()
+		if capture.written {
+			l.Flush()
+			panic(l.newPanicError(msg, err, capture.entry))
+		}
+	}
 }
 
 // DebugMsg logs a simple message at debug level
 func (l *Logger) DebugMsg(msg string, values ...any) {
-	l.Debug().Msg(msg, values...)
+	if !debugEnabled {
+		return
+	}
+	l.logMsg(l.zl.Debug(), false, msg, values)
 }
 
 // InfoMsg logs a simple message at info level
 func (l *Logger) InfoMsg(msg string, values ...any) {
-	l.Info().Msg(msg, values...)
+	l.logMsg(l.zl.Info(), false, msg, values)
+}
+
+// NoticeMsg logs a simple message at notice level
+func (l *Logger) NoticeMsg(msg string, values ...any) {
+	l.logMsg(l.levelEvent(NoticeLevel), false, msg, values)
 }
 
 // WarnMsg logs a simple message at warn level
 func (l *Logger) WarnMsg(msg string, values ...any) {
-	l.Warn().Msg(msg, values...)
+	l.logMsg(l.zl.Warn(), false, msg, values)
 }
 
 // ErrorMsg logs a simple message at error level
 func (l *Logger) ErrorMsg(msg string, values ...any) {
-	l.Error().Msg(msg, values...)
+	l.logMsg(l.zl.Error(), true, msg, values)
+}
+
+// CriticalMsg logs a simple message at critical level
+func (l *Logger) CriticalMsg(msg string, values ...any) {
+	l.logMsg(l.levelEvent(CriticalLevel), true, msg, values)
 }
 
-// FatalMsg logs a simple message at fatal level, then calls os.Exit(1)
+// FatalMsg logs a simple message at fatal level, then exits with code 1
 func (l *Logger) FatalMsg(msg string, values ...any) {
-	l.Fatal().Msg(msg, values...)
+	l.logMsg(l.zl.WithLevel(zerolog.FatalLevel), true, msg, values)
+	l.exit(1)
 }
 
-// PanicMsg logs a simple message at panic level, then panics
+// PanicMsg logs a simple message at panic level, then flushes the output and
+// panics with a *PanicError like Panic
 func (l *Logger) PanicMsg(msg string, values ...any) {
-	l.Panic().Msg(msg, values...)
+	l.Panic().send(msg, values)
 }
 
 // TraceMsg logs a simple message at trace level
 func (l *Logger) TraceMsg(msg string, values ...any) {
-	l.Trace().Msg(msg, values...)
+	if !debugEnabled {
+		return
+	}
+	l.logMsg(l.zl.Trace(), false, msg, values)
+}
+
+// logMsg writes a simple message. Literal messages that need no caller or
+// stack trace are written directly to the event, skipping the builder and fmt.
+func (l *Logger) logMsg(event *zerolog.Event, errorLevel bool, msg string, values []any) {
+	if event == nil {
+		return
+	}
+	stack := errorLevel && l.stackTrace
+	if len(values) == 0 && !l.withCaller && !stack {
+		event.Msg(msg)
+		return
+	}
+	lb := l.newLogBuilder(event)
+	lb.stack = stack
+	lb.send(msg, values)
 }
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"errors"
 	"io"
 	"testing"
 )
@@ -137,3 +138,43 @@ func BenchmarkLoggerWithCaller(b *testing.B) {
 		logger.InfoMsg("Log with caller information")
 	}
 }
+
+// Benchmark to measure performance of an error log, whose builder is pooled
+func BenchmarkErrorLog(b *testing.B) {
+	logger := New(Config{
+		Level:       InfoLevel,
+		Pretty:      false,
+		WithCaller:  false,
+		Output:      io.Discard,
+		ServiceName: "benchmark-service",
+	})
+	err := errors.New("connection refused")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		logger.Error().
+			WithError(err).
+			Str("operation", "connect").
+			Msg("This is an error log message")
+	}
+}
+
+// Benchmark to measure the cost of a formatted log below the logger level
+func BenchmarkDisabledFormattedLog(b *testing.B) {
+	logger := New(Config{
+		Level:       WarnLevel,
+		Pretty:      false,
+		WithCaller:  true,
+		Output:      io.Discard,
+		ServiceName: "benchmark-service",
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		logger.Debug().
+			Str("key", "value").
+			Msg("Formatted message with value: %d and %s", 42, "text")
+	}
+}
@@ -3,14 +3,27 @@ package logger
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/rs/zerolog"
 )
 
 // TestLogLevels tests that log levels work correctly
 func TestLogLevels(t *testing.T) {
+	skipWithoutDebug(t)
+
 	var buf bytes.Buffer
 
 	// Create a logger with custom output to capture messages
@@ -138,6 +151,8 @@ func TestWithFields(t *testing.T) {
 
 // TestFormattedLogs tests logging with formatted messages
 func TestFormattedLogs(t *testing.T) {
+	skipWithoutDebug(t)
+
 	var buf bytes.Buffer
 
 	log := New(Config{
@@ -242,6 +257,22 @@ func assertLogContains(t *testing.T, logLine, expectedText, expectedLevel string
 	}
 }
 
+// Helper function to verify log does not contain specific text
+func assertLogNotContains(t *testing.T, logLine, unexpectedText string) {
+	if strings.Contains(logLine, unexpectedText) {
+		t.Errorf("Log should not contain '%s', but contains: %s", unexpectedText, logLine)
+	}
+}
+
+// skipWithoutDebug skips a test expecting Trace or Debug output in builds with
+// the easylogger_nodebug tag, where they are compiled out
+func skipWithoutDebug(t *testing.T) {
+	t.Helper()
+	if !debugEnabled {
+		t.Skip("Trace and Debug are compiled out by the easylogger_nodebug tag")
+	}
+}
+
 // Mock error for testing error logging
 type mockError struct{}
 
@@ -345,6 +376,8 @@ func TestBuilderPattern(t *testing.T) {
 
 // TestOtherLogLevels tests the log levels that aren't tested elsewhere
 func TestOtherLogLevels(t *testing.T) {
+	skipWithoutDebug(t)
+
 	var buf bytes.Buffer
 
 	log := New(Config{
@@ -372,6 +405,8 @@ func TestOtherLogLevels(t *testing.T) {
 
 // TestMessageMethods tests the convenience message methods
 func TestMessageMethods(t *testing.T) {
+	skipWithoutDebug(t)
+
 	var buf bytes.Buffer
 
 	log := New(Config{
@@ -493,6 +528,8 @@ func TestLogLevelParsing(t *testing.T) {
 		{"error", ErrorLevel},
 		{"fatal", FatalLevel},
 		{"panic", PanicLevel},
+		{"notice", NoticeLevel},
+		{"critical", CriticalLevel},
 		// Test uppercase versions too
 		{"TRACE", TraceLevel},
 		{"DEBUG", DebugLevel},
@@ -520,6 +557,18 @@ func TestLogLevelParsing(t *testing.T) {
 		t.Error("ParseLevel(invalid) should return an error")
 	}
 
+	// Test numeric levels in zerolog numbering
+	for n, want := range map[string]Level{"-1": TraceLevel, "0": DebugLevel, "1": InfoLevel, "3": ErrorLevel, "5": PanicLevel} {
+		if level, err := ParseLevel(n); err != nil || level != want {
+			t.Errorf("ParseLevel(%s) = %v, %v, want %v", n, level, err, want)
+		}
+	}
+	for _, n := range []string{"7", "-2", "1000", "1.5"} {
+		if _, err := ParseLevel(n); err == nil {
+			t.Errorf("ParseLevel(%s) should return an error", n)
+		}
+	}
+
 	// Test String method for each level
 	stringTests := []struct {
 		level    Level
@@ -532,6 +581,8 @@ func TestLogLevelParsing(t *testing.T) {
 		{ErrorLevel, "error"},
 		{FatalLevel, "fatal"},
 		{PanicLevel, "panic"},
+		{NoticeLevel, "notice"},
+		{CriticalLevel, "critical"},
 	}
 
 	for _, tc := range stringTests {
@@ -542,6 +593,243 @@ func TestLogLevelParsing(t *testing.T) {
 	}
 }
 
+// TestNoticeAndCriticalLevels tests the levels zerolog does not know
+func TestNoticeAndCriticalLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf, StackTrace: true})
+
+	log.Notice().Str("user", "u1").Msg("config reloaded")
+	assertLogContains(t, buf.String(), `"user":"u1"`, "notice")
+
+	buf.Reset()
+	log.Critical().Msg("replica lost")
+	assertLogContains(t, buf.String(), "replica lost", "critical")
+	assertLogContains(t, buf.String(), `"stack"`, "critical")
+
+	buf.Reset()
+	log.NoticeMsg("notice %d", 1)
+	assertLogContains(t, buf.String(), "notice 1", "notice")
+	buf.Reset()
+	log.CriticalMsg("critical")
+	assertLogContains(t, buf.String(), "critical", "critical")
+
+	// Notice is between info and warn
+	buf.Reset()
+	log.SetLevel(NoticeLevel)
+	log.Info().Msg("hidden")
+	if buf.Len() > 0 {
+		t.Errorf("Info should be filtered at notice level, got: %s", buf.String())
+	}
+	log.Notice().Msg("shown")
+	assertLogContains(t, buf.String(), "shown", "notice")
+	if !log.Enabled(WarnLevel) || log.Enabled(InfoLevel) {
+		t.Error("Unexpected enabled levels at notice level")
+	}
+
+	// Critical is between error and fatal
+	buf.Reset()
+	log.SetLevel(CriticalLevel)
+	log.Error().Msg("hidden")
+	log.Notice().Msg("hidden")
+	if buf.Len() > 0 {
+		t.Errorf("Error and notice should be filtered at critical level, got: %s", buf.String())
+	}
+	log.Critical().Msg("shown")
+	assertLogContains(t, buf.String(), "shown", "critical")
+
+	buf.Reset()
+	log.SetLevel(WarnLevel)
+	log.Notice().Msg("hidden")
+	if buf.Len() > 0 || log.Notice().Enabled() {
+		t.Errorf("Notice should be filtered at warn level, got: %s", buf.String())
+	}
+
+	levels := []Level{TraceLevel, DebugLevel, InfoLevel, NoticeLevel, WarnLevel, ErrorLevel, CriticalLevel, FatalLevel, PanicLevel}
+	for i := 1; i < len(levels); i++ {
+		if levels[i-1].Severity() >= levels[i].Severity() {
+			t.Errorf("Expected %v to be less severe than %v", levels[i-1], levels[i])
+		}
+	}
+}
+
+// TestPrettyLevels tests the short level names of the pretty formatter
+func TestPrettyLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: PrettyFormatter{NoColor: true}.Format(&buf)})
+
+	log.Notice().Msg("n")
+	log.Critical().Msg("c")
+	log.Warn().Msg("w")
+	for _, want := range []string{"NTC n", "CRT c", "WRN w"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in output, got: %s", want, buf.String())
+		}
+	}
+}
+
+// TestCustomLevels tests levels registered with RegisterLevel
+func TestCustomLevels(t *testing.T) {
+	audit, err := RegisterLevel("Audit", 25, 35)
+	if err != nil {
+		t.Fatalf("RegisterLevel failed: %v", err)
+	}
+	if _, err := RegisterLevel("audit", 26, 0); err == nil {
+		t.Error("Expected an error registering a taken name")
+	}
+	if _, err := RegisterLevel("warning", 20, 0); err == nil {
+		t.Error("Expected an error registering a built-in level name")
+	}
+	if level, err := ParseLevel("AUDIT"); err != nil || level != audit {
+		t.Errorf("ParseLevel(AUDIT) = %v, %v, want %v", level, err, audit)
+	}
+	if audit.String() != "audit" || audit.Severity() != 25 {
+		t.Errorf("Unexpected level %s with severity %d", audit, audit.Severity())
+	}
+
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf})
+
+	log.WithLevel(audit).Str("user", "u1").Msg("role changed")
+	assertLogContains(t, buf.String(), "role changed", "audit")
+
+	// Audit is between warn and error
+	buf.Reset()
+	log.SetLevel(audit)
+	log.Warn().Msg("hidden")
+	if buf.Len() > 0 {
+		t.Errorf("Warn should be filtered at audit level, got: %s", buf.String())
+	}
+	log.WithLevel(audit).Msg("shown")
+	assertLogContains(t, buf.String(), "shown", "audit")
+	buf.Reset()
+	log.WithLevel(ErrorLevel).Msg("error")
+	assertLogContains(t, buf.String(), "error", "error")
+
+	buf.Reset()
+	pretty := New(Config{Level: InfoLevel, Output: PrettyFormatter{NoColor: true}.Format(&buf)})
+	pretty.WithLevel(audit).Msg("a")
+	if !strings.Contains(buf.String(), "AUD a") {
+		t.Errorf("Expected the short level name in pretty output, got: %s", buf.String())
+	}
+}
+
+// TestLogWithoutLevel tests raw entries without level
+func TestLogWithoutLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: ErrorLevel, Output: &buf})
+
+	obs := NewObserver()
+	log = log.WithObserver(obs)
+
+	log.Log().Str("metric", "requests").Int("value", 42).Msg("")
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if _, ok := entry["level"]; ok {
+		t.Errorf("Expected no level field, got: %s", buf.String())
+	}
+	if entry["metric"] != "requests" || entry["value"] != 42.0 {
+		t.Errorf("Unexpected entry: %s", buf.String())
+	}
+	if entries := obs.Entries(); len(entries) != 1 || entries[0].Level != NoLevel {
+		t.Errorf("Expected one observed entry without level, got %+v", entries)
+	}
+	if NoLevel.String() != "" {
+		t.Errorf("Expected an empty NoLevel name, got %q", NoLevel.String())
+	}
+
+	buf.Reset()
+	log.SetLevel(Level(zerolog.Disabled))
+	log.Log().Msg("disabled")
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output from a disabled logger, got: %s", buf.String())
+	}
+}
+
+// TestSyslogSeverity tests the numeric severity field
+func TestSyslogSeverity(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithSyslogSeverity(true))
+
+	tests := []struct {
+		lb       *LogBuilder
+		level    string
+		severity string
+	}{
+		{log.Info(), "info", `"severity":6`},
+		{log.Notice(), "notice", `"severity":5`},
+		{log.Warn(), "warn", `"severity":4`},
+		{log.Error(), "error", `"severity":3`},
+		{log.Critical(), "critical", `"severity":2`},
+	}
+	for _, tt := range tests {
+		buf.Reset()
+		tt.lb.Msg("message")
+		assertLogContains(t, buf.String(), tt.severity, tt.level)
+	}
+
+	buf.Reset()
+	log.Log().Msg("raw")
+	assertLogNotContains(t, buf.String(), "severity")
+
+	for level, want := range map[Level]int{TraceLevel: 7, DebugLevel: 7, FatalLevel: 1, PanicLevel: 0} {
+		if got := level.SyslogSeverity(); got != want {
+			t.Errorf("%v.SyslogSeverity() = %d, want %d", level, got, want)
+		}
+	}
+}
+
+// TestConcurrentLevelRegistration tests that a name is registered once under
+// concurrent registrations
+func TestConcurrentLevelRegistration(t *testing.T) {
+	var wg sync.WaitGroup
+	var registered, aliased atomic.Int32
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := RegisterLevel("concurrent", 25, 0); err == nil {
+				registered.Add(1)
+			}
+			if err := RegisterLevelAlias("concurrent_alias", InfoLevel); err == nil {
+				aliased.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if registered.Load() != 1 || aliased.Load() != 1 {
+		t.Errorf("Expected a single registration of each name, got %d levels and %d aliases", registered.Load(), aliased.Load())
+	}
+}
+
+// TestLevelAliases tests the aliases registered with RegisterLevelAlias
+func TestLevelAliases(t *testing.T) {
+	if err := RegisterLevelAlias("Verbose", DebugLevel); err != nil {
+		t.Fatalf("RegisterLevelAlias failed: %v", err)
+	}
+	if err := RegisterLevelAlias("err", ErrorLevel); err != nil {
+		t.Fatalf("RegisterLevelAlias failed: %v", err)
+	}
+	for _, taken := range []string{"verbose", "error", "warning", ""} {
+		if err := RegisterLevelAlias(taken, InfoLevel); err == nil {
+			t.Errorf("Expected an error registering alias %q", taken)
+		}
+	}
+	if level, err := ParseLevel("VERBOSE"); err != nil || level != DebugLevel {
+		t.Errorf("ParseLevel(VERBOSE) = %v, %v, want debug", level, err)
+	}
+	if ErrorLevel.String() != "error" {
+		t.Error("Aliases should not change the level name")
+	}
+
+	t.Setenv(EnvLogLevel, "err")
+	log := NewFromEnv()
+	if log.Enabled(WarnLevel) || !log.Enabled(ErrorLevel) {
+		t.Error("Expected the error level from the LOG_LEVEL alias")
+	}
+}
+
 // TestWithMethod tests the With method for adding context
 func TestWithMethod(t *testing.T) {
 	var buf bytes.Buffer
@@ -723,3 +1011,801 @@ func TestAddField(t *testing.T) {
 	assertLogContains(t, logData, "true", "")
 	assertLogContains(t, logData, "3.14", "")
 }
+
+// TestTimestampOptions tests disabling and renaming the timestamp field
+func TestTimestampOptions(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithTimestamp(false),
+	)
+	log.InfoMsg("no timestamp")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if _, ok := logData["time"]; ok {
+		t.Error("Log should not contain 'time' field when timestamp is disabled")
+	}
+	buf.Reset()
+
+	log = NewBuilder().
+		WithOutput(&buf).
+		WithCaller(false).
+		WithTimestampFieldName("@timestamp").
+		Build()
+	log.InfoMsg("renamed timestamp")
+
+	logData = map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if _, ok := logData["@timestamp"]; !ok {
+		t.Errorf("Log should contain '@timestamp' field, got: %s", buf.String())
+	}
+	if _, ok := logData["time"]; ok {
+		t.Error("Log should not contain 'time' field when timestamp is renamed")
+	}
+}
+
+// TestCustomFieldNames tests renaming the standard fields
+func TestCustomFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:              InfoLevel,
+		WithCaller:         true,
+		Output:             &buf,
+		ServiceName:        "test-service",
+		TimestampFieldName: "@timestamp",
+		LevelFieldName:     "severity",
+		MessageFieldName:   "msg",
+		CallerFieldName:    "src",
+		ServiceFieldName:   "app",
+	})
+	// Creating another logger must not rename the fields of the first one
+	New(Config{Level: InfoLevel, Output: io.Discard})
+
+	log.InfoMsg("renamed fields")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+
+	for _, key := range []string{"@timestamp", "severity", "msg", "src", "app"} {
+		if _, ok := logData[key]; !ok {
+			t.Errorf("Log should contain '%s' field, got: %s", key, buf.String())
+		}
+	}
+	for _, key := range []string{"time", "level", "message", "caller", "service"} {
+		if _, ok := logData[key]; ok {
+			t.Errorf("Log should not contain '%s' field, got: %s", key, buf.String())
+		}
+	}
+	if logData["severity"] != "info" || logData["app"] != "test-service" {
+		t.Errorf("Unexpected field values: %s", buf.String())
+	}
+}
+
+// TestCustomFieldNamesPretty tests that the pretty format still renders the
+// renamed standard fields by their role
+func TestCustomFieldNamesPretty(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{
+		Level:            InfoLevel,
+		Pretty:           true,
+		Output:           &buf,
+		LevelFieldName:   "severity",
+		MessageFieldName: "msg",
+	})
+
+	log.Warn().Msg("renamed fields")
+	out := buf.String()
+	if !strings.Contains(out, "WRN") || !strings.Contains(out, "renamed fields") || strings.Contains(out, "msg=") {
+		t.Errorf("Expected the level and message rendered in place, got %q", out)
+	}
+}
+
+// TestUnixTimeFormats tests numeric epoch timestamps
+func TestUnixTimeFormats(t *testing.T) {
+	var buf bytes.Buffer
+
+	testCases := []struct {
+		format string
+		min    float64
+	}{
+		{TimeFormatUnix, 1e9},
+		{TimeFormatUnixMs, 1e12},
+	}
+
+	for _, tc := range testCases {
+		buf.Reset()
+		log := New(Config{
+			Level:      InfoLevel,
+			WithCaller: false,
+			Output:     &buf,
+			TimeFormat: tc.format,
+		})
+		log.InfoMsg("epoch timestamp")
+
+		var logData map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+			t.Fatalf("Could not parse log as JSON: %v", err)
+		}
+		ts, ok := logData["time"].(float64)
+		if !ok {
+			t.Errorf("Expected numeric 'time' field for format %s, got: %s", tc.format, buf.String())
+			continue
+		}
+		if ts < tc.min || ts > tc.min*10 {
+			t.Errorf("Unexpected timestamp %v for format %s", ts, tc.format)
+		}
+	}
+
+	// Test the format can be set from the environment
+	origFormat := os.Getenv(EnvLogTimeFormat)
+	defer os.Setenv(EnvLogTimeFormat, origFormat)
+
+	os.Setenv(EnvLogTimeFormat, TimeFormatUnixMs)
+	if log := NewFromEnv(); log.cfg.TimeFormat != TimeFormatUnixMs {
+		t.Errorf("Expected time format %q from env, got %q", TimeFormatUnixMs, log.cfg.TimeFormat)
+	}
+
+	// The format is per logger: creating another logger does not change it
+	buf.Reset()
+	log := New(Config{Level: InfoLevel, Output: &buf, TimeFormat: TimeFormatUnix})
+	New(Config{Output: io.Discard, TimeFormat: "2006"})
+	log.InfoMsg("after another logger")
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if _, ok := logData["time"].(float64); !ok {
+		t.Errorf("Expected a numeric timestamp, got: %s", buf.String())
+	}
+}
+
+// TestTimeDiff tests the elapsed time field
+func TestTimeDiff(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	start := time.Now().Add(-50 * time.Millisecond)
+	log.Info().TimeDiff("elapsed", start).Msg("operation finished")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	elapsed, ok := logData["elapsed"].(float64)
+	if !ok {
+		t.Fatalf("Log should contain numeric 'elapsed' field, got: %s", buf.String())
+	}
+	if elapsed < 50 {
+		t.Errorf("Expected elapsed >= 50ms, got %v", elapsed)
+	}
+
+	// The elapsed time is measured with the logger clock
+	buf.Reset()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	log = NewWithOptions(WithOutput(&buf), WithClock(func() time.Time { return now }))
+	log.Info().TimeDiff("elapsed", now.Add(-2*time.Second)).Msg("operation finished")
+	assertLogContains(t, buf.String(), `"elapsed":2000`, "info")
+}
+
+// TestDurFormats tests the duration rendering formats
+func TestDurFormats(t *testing.T) {
+	var buf bytes.Buffer
+
+	testCases := []struct {
+		format   string
+		expected any
+	}{
+		{"", 1500.0},
+		{DurationFormatMs, 1500.0},
+		{DurationFormatSeconds, 1.5},
+		{DurationFormatString, "1.5s"},
+	}
+
+	for _, tc := range testCases {
+		buf.Reset()
+		log := NewWithOptions(
+			WithOutput(&buf),
+			WithCaller(false),
+			WithDurationFormat(tc.format),
+		)
+		log.Info().Dur("took", 1500*time.Millisecond).Msg("duration")
+
+		var logData map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+			t.Fatalf("Could not parse log as JSON: %v", err)
+		}
+		if logData["took"] != tc.expected {
+			t.Errorf("Format %q: expected %v, got %v", tc.format, tc.expected, logData["took"])
+		}
+	}
+}
+
+// TestFloatFields tests the float field methods
+func TestFloatFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Info().
+		Float64("total", 99.95).
+		Float32("ratio", 0.5).
+		Msg("metrics")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if logData["total"] != 99.95 {
+		t.Errorf("Expected total 99.95, got %v", logData["total"])
+	}
+	if logData["ratio"] != 0.5 {
+		t.Errorf("Expected ratio 0.5, got %v", logData["ratio"])
+	}
+}
+
+// TestIntegerFields tests the integer-width field methods
+func TestIntegerFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Info().
+		Int8("i8", -8).
+		Int16("i16", -16).
+		Int32("i32", -32).
+		Int64("i64", -9007199254740993).
+		Uint("u", 1).
+		Uint8("u8", 8).
+		Uint16("u16", 16).
+		Uint32("u32", 32).
+		Uint64("u64", 18446744073709551615).
+		Msg("integers")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"i8":-8`, "")
+	assertLogContains(t, logData, `"i16":-16`, "")
+	assertLogContains(t, logData, `"i32":-32`, "")
+	assertLogContains(t, logData, `"i64":-9007199254740993`, "")
+	assertLogContains(t, logData, `"u":1`, "")
+	assertLogContains(t, logData, `"u8":8`, "")
+	assertLogContains(t, logData, `"u16":16`, "")
+	assertLogContains(t, logData, `"u32":32`, "")
+	assertLogContains(t, logData, `"u64":18446744073709551615`, "")
+}
+
+// TestRawJSON tests embedding pre-serialized JSON
+func TestRawJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Info().RawJSON("body", []byte(`{"id":1,"tags":["a","b"]}`)).Msg("request body")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	body, ok := logData["body"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected 'body' to be a JSON object, got: %s", buf.String())
+	}
+	if body["id"] != 1.0 {
+		t.Errorf("Expected body.id 1, got %v", body["id"])
+	}
+}
+
+// TestDict tests nested object fields
+func TestDict(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Info().
+		Dict("http", func(d *LogBuilder) {
+			d.Str("method", "GET").Int("status", 200)
+		}).
+		Msg("request handled")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	httpData, ok := logData["http"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected 'http' to be a JSON object, got: %s", buf.String())
+	}
+	if httpData["method"] != "GET" || httpData["status"] != 200.0 {
+		t.Errorf("Unexpected nested fields: %v", httpData)
+	}
+}
+
+// TestNetworkFields tests the IP and MAC address field methods
+func TestNetworkFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	_, prefix, _ := net.ParseCIDR("10.0.0.0/8")
+	mac, _ := net.ParseMAC("00:1a:2b:3c:4d:5e")
+
+	log.Info().
+		IPAddr("peer", net.ParseIP("192.168.1.10")).
+		IPPrefix("network", *prefix).
+		MACAddr("mac", mac).
+		Msg("peer connected")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"peer":"192.168.1.10"`, "")
+	assertLogContains(t, logData, `"network":"10.0.0.0/8"`, "")
+	assertLogContains(t, logData, `"mac":"00:1a:2b:3c:4d:5e"`, "")
+}
+
+// TestErrs tests logging multiple errors
+func TestErrs(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	errs := []error{errors.New("first failure"), &mockError{}}
+	log.Error().Errs("failures", errs).Msg("batch failed")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	failures, ok := logData["failures"].([]any)
+	if !ok || len(failures) != 2 {
+		t.Fatalf("Expected 'failures' array with 2 entries, got: %s", buf.String())
+	}
+	if failures[0] != "first failure" || failures[1] != "mock error" {
+		t.Errorf("Unexpected failures: %v", failures)
+	}
+}
+
+// TestErrorChain tests expanding wrapped errors
+func TestErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithErrorChain(true),
+	)
+
+	root := errors.New("connection refused")
+	err := fmt.Errorf("query users: %w", fmt.Errorf("dial db: %w", root))
+	log.Error().WithError(err).Msg("request failed")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	chain, ok := logData[ErrorChainFieldName].([]any)
+	if !ok || len(chain) != 3 {
+		t.Fatalf("Expected error chain with 3 entries, got: %s", buf.String())
+	}
+	if chain[2] != "connection refused" {
+		t.Errorf("Expected last chain entry to be the root cause, got %v", chain[2])
+	}
+	if logData[ErrorRootFieldName] != "connection refused" {
+		t.Errorf("Expected root cause 'connection refused', got %v", logData[ErrorRootFieldName])
+	}
+
+	// Unwrapped errors and disabled chains should not add the fields
+	buf.Reset()
+	log.Error().WithError(root).Msg("plain error")
+	if strings.Contains(buf.String(), ErrorChainFieldName) {
+		t.Errorf("Unwrapped error should not produce a chain: %s", buf.String())
+	}
+
+	buf.Reset()
+	New(Config{Output: &buf}).Error().WithError(err).Msg("chain disabled")
+	if strings.Contains(buf.String(), ErrorChainFieldName) {
+		t.Errorf("Chain should not be expanded when disabled: %s", buf.String())
+	}
+}
+
+// TestWhen tests conditional logging
+func TestWhen(t *testing.T) {
+	skipWithoutDebug(t)
+
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      DebugLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Debug().When(false).Str("key", "value").Msg("hidden message")
+	if buf.Len() > 0 {
+		t.Errorf("Message should not have been logged, got: %s", buf.String())
+	}
+
+	log.Debug().When(true).Str("key", "value").Msg("visible message")
+	assertLogContains(t, buf.String(), "visible message", "debug")
+}
+
+// TestEnabled tests level checks and no-op disabled builders
+func TestEnabled(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	if log.Enabled(DebugLevel) {
+		t.Error("Debug level should not be enabled")
+	}
+	if !log.Enabled(WarnLevel) {
+		t.Error("Warn level should be enabled")
+	}
+	if log.Debug().Enabled() {
+		t.Error("Debug builder should not be enabled")
+	}
+	if !log.Info().Enabled() {
+		t.Error("Info builder should be enabled")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Debug().
+			Str("key", "value").
+			Int("count", 1).
+			Dict("nested", func(d *LogBuilder) { d.Bool("ok", true) }).
+			Stack().
+			Msg("disabled message")
+	})
+	if allocs != 0 {
+		t.Errorf("Disabled builder should not allocate, got %v allocs", allocs)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Disabled message should not be logged, got: %s", buf.String())
+	}
+}
+
+// TestMsgLiteralAndFormatted tests literal and formatted messages
+func TestMsgLiteralAndFormatted(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	log.Info().Msg("GET /search?q=a%20b 100%")
+	assertLogContains(t, buf.String(), "GET /search?q=a%20b 100%", "info")
+	buf.Reset()
+
+	log.InfoMsg("disk at 95%")
+	assertLogContains(t, buf.String(), "disk at 95%", "info")
+	buf.Reset()
+
+	log.Info().Msg("Value: %d", 42)
+	assertLogContains(t, buf.String(), "Value: 42", "info")
+	buf.Reset()
+
+	log.Info().Msgf("progress %d%%", 50)
+	assertLogContains(t, buf.String(), "progress 50%", "info")
+}
+
+// TestMsgFunc tests lazy message evaluation
+func TestMsgFunc(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	called := false
+	log.Debug().MsgFunc(func() string {
+		called = true
+		return "expensive debug message"
+	})
+	log.Info().When(false).MsgFunc(func() string {
+		called = true
+		return "expensive skipped message"
+	})
+	if called {
+		t.Error("MsgFunc should not be called for disabled logs")
+	}
+
+	log.Info().MsgFunc(func() string { return "lazy message" })
+	assertLogContains(t, buf.String(), "lazy message", "info")
+}
+
+// TestLazyField tests lazily computed field values
+func TestLazyField(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     &buf,
+	})
+
+	called := false
+	log.Debug().LazyField("dump", func() any {
+		called = true
+		return "expensive"
+	}).Msg("debug message")
+	if called {
+		t.Error("LazyField callback should not be called for disabled logs")
+	}
+
+	log.Info().LazyField("dump", func() any {
+		return map[string]int{"items": 3}
+	}).Msg("info message")
+	assertLogContains(t, buf.String(), `"dump":{"items":3}`, "info")
+}
+
+// TestCallerLocation tests that the caller points to the calling code
+func TestCallerLocation(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: true,
+		Output:     &buf,
+	})
+
+	log.Info().Msg("builder message")
+	log.Info().Msgf("formatted %s", "message")
+	log.InfoMsg("direct message")
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var logData map[string]any
+		if err := json.Unmarshal([]byte(line), &logData); err != nil {
+			t.Fatalf("Could not parse log as JSON: %v", err)
+		}
+		caller, _ := logData["caller"].(string)
+		if !strings.Contains(caller, "logger_test.go:") {
+			t.Errorf("Expected caller in logger_test.go, got %q", caller)
+		}
+	}
+}
+
+// TestCallerFunc tests adding the function of the caller
+func TestCallerFunc(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: true,
+		CallerFunc: true,
+		Output:     &buf,
+	})
+
+	log.Info().Msg("builder message")
+	assertLogContains(t, buf.String(), `"caller_func":"logger.TestCallerFunc"`, "info")
+
+	buf.Reset()
+	func() { log.InfoMsg("direct message") }()
+	assertLogContains(t, buf.String(), `"caller_func":"logger.TestCallerFunc.func1"`, "info")
+}
+
+// TestPerEntryCaller tests adding the caller to a single entry
+func TestPerEntryCaller(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf})
+
+	log.Info().Msg("without caller")
+	assertLogNotContains(t, buf.String(), `"caller"`)
+
+	buf.Reset()
+	log.Info().Caller().Msg("with caller")
+	assertLogContains(t, buf.String(), `"caller":"`, "info")
+	assertLogContains(t, buf.String(), "logger_test.go:", "")
+
+	// The builder is reused from the pool without the caller flag
+	buf.Reset()
+	log.Info().Msg("without caller again")
+	assertLogNotContains(t, buf.String(), `"caller"`)
+}
+
+// logWrapped logs like a package wrapping the logger
+func logWrapped(log *Logger) {
+	log.Info().Caller().CallerSkipFrame(1).Msg("wrapped")
+}
+
+// TestCallerSkipFrame tests reporting the caller of a function wrapping the logger
+func TestCallerSkipFrame(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf})
+
+	_, _, line, _ := runtime.Caller(0)
+	logWrapped(log)
+	assertLogContains(t, buf.String(), fmt.Sprintf(`logger_test.go:%d"`, line+1), "info")
+}
+
+// TestCallerTrimPrefix tests removing a prefix from caller paths
+func TestCallerTrimPrefix(t *testing.T) {
+	tests := []struct {
+		file, prefix, expected string
+	}{
+		{"github.com/acme/app/internal/api/handler.go", "github.com/acme/app", "internal/api/handler.go"},
+		{"/go/src/github.com/acme/app/main.go", "github.com/acme/app", "main.go"},
+		{"/go/src/github.com/acme/application/main.go", "github.com/acme/app", "/go/src/github.com/acme/application/main.go"},
+		{"/go/src/xgithub.com/acme/app/main.go", "github.com/acme/app", "/go/src/xgithub.com/acme/app/main.go"},
+		{"/build/cmd/main.go", "", "/build/cmd/main.go"},
+	}
+	for _, tt := range tests {
+		if got := trimCallerPath(tt.file, tt.prefix); got != tt.expected {
+			t.Errorf("trimCallerPath(%q, %q) = %q, expected %q", tt.file, tt.prefix, got, tt.expected)
+		}
+	}
+
+	// Without -trimpath, the root directory of the module is removed
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/acme/app\n\ngo 1.24\n"), 0o644)
+	os.MkdirAll(filepath.Join(root, "internal", "api"), 0o755)
+	for file, expected := range map[string]string{
+		filepath.Join(root, "internal", "api", "handler.go"): "internal/api/handler.go",
+		filepath.Join(root, "main.go"):                       "main.go",
+	} {
+		if got := trimCallerPath(file, "github.com/acme/app"); got != expected {
+			t.Errorf("trimCallerPath(%q) = %q, expected %q", file, got, expected)
+		}
+	}
+	if file := filepath.Join(root, "main.go"); trimCallerPath(file, "github.com/acme/other") != file {
+		t.Errorf("Expected paths in another module to be kept")
+	}
+
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCallerTrimPrefix(filepath.Dir(packageDir)+"/"))
+	log.Info().Msg("trimmed")
+	assertLogContains(t, buf.String(), `"caller":"logger/logger_test.go:`, "info")
+
+	buf.Reset()
+	log = NewWithOptions(WithOutput(&buf), WithCallerTrimPrefix("github.com/jdroa1998/easy-logger"))
+	log.Info().Msg("trimmed")
+	assertLogContains(t, buf.String(), `"caller":"logger/logger_test.go:`, "info")
+}
+
+// TestSimpleMessageFastPath tests that literal messages do not allocate
+func TestSimpleMessageFastPath(t *testing.T) {
+	log := New(Config{
+		Level:      InfoLevel,
+		WithCaller: false,
+		Output:     io.Discard,
+	})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		log.InfoMsg("simple message")
+	})
+	if allocs != 0 {
+		t.Errorf("Simple message should not allocate, got %v allocs", allocs)
+	}
+}
+
+// TestDisabledFormattedMessages tests that disabled formatted calls do nothing
+func TestDisabledFormattedMessages(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{
+		Level:      WarnLevel,
+		WithCaller: true,
+		Output:     &buf,
+	})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		log.DebugMsg("value %s %d", "a", 1)
+		log.InfoMsg("value %s %d", "a", 1)
+		log.Info().Msgf("value %s %d", "a", 1)
+		log.Trace().Str("key", "value").Msg("value %s %d", "a", 1)
+	})
+	if allocs != 0 {
+		t.Errorf("Disabled formatted calls should not allocate, got %v allocs", allocs)
+	}
+	if buf.Len() > 0 {
+		t.Errorf("Disabled calls should not write, got: %s", buf.String())
+	}
+}
+
+// TestLevelEncoding tests the flag.Value and text encoding interfaces of Level
+func TestLevelEncoding(t *testing.T) {
+	var level Level
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&level, "log-level", "minimum log level")
+	if err := fs.Parse([]string{"-log-level", "warn"}); err != nil || level != WarnLevel {
+		t.Errorf("Expected warn from the flag, got %v, %v", level, err)
+	}
+	if err := fs.Parse([]string{"-log-level", "loud"}); err == nil {
+		t.Error("Expected an error for an invalid flag value")
+	}
+
+	var cfg struct {
+		Level Level `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(`{"level":"ERROR"}`), &cfg); err != nil || cfg.Level != ErrorLevel {
+		t.Errorf("Expected error from JSON, got %v, %v", cfg.Level, err)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &cfg); err == nil {
+		t.Error("Expected an error for an invalid JSON level")
+	}
+	cfg.Level = NoticeLevel
+	data, err := json.Marshal(cfg)
+	if err != nil || string(data) != `{"level":"notice"}` {
+		t.Errorf("Unexpected JSON %s, %v", data, err)
+	}
+	if _, err := Level(100).MarshalText(); err == nil {
+		t.Error("Expected an error marshaling an unknown level")
+	}
+}
+
+// TestConfigDecoding tests decoding a Config embedded in an application config
+func TestConfigDecoding(t *testing.T) {
+	var appConfig struct {
+		Port int    `json:"port"`
+		Log  Config `json:"log"`
+	}
+	appConfig.Log = DefaultConfig()
+	data := `{"port":8080,"log":{"level":"warn","service_name":"api","key_collision":"keep_first","redact_keys":["password"],"progress_interval":5000000000}}`
+	if err := json.Unmarshal([]byte(data), &appConfig); err != nil {
+		t.Fatalf("Could not decode config: %v", err)
+	}
+	cfg := appConfig.Log
+	if cfg.Level != WarnLevel || cfg.ServiceName != "api" || cfg.KeyCollision != KeyCollisionKeepFirst {
+		t.Errorf("Unexpected decoded config: %+v", cfg)
+	}
+	if len(cfg.RedactKeys) != 1 || cfg.ProgressInterval != 5*time.Second {
+		t.Errorf("Unexpected decoded config: %+v", cfg)
+	}
+	if !cfg.WithCaller || cfg.Output != os.Stderr {
+		t.Error("Fields missing from the JSON should keep their defaults")
+	}
+
+	if err := json.Unmarshal([]byte(`{"key_collision":"merge"}`), &cfg); err == nil {
+		t.Error("Expected an error for an invalid key collision policy")
+	}
+
+	encoded, err := json.Marshal(DefaultConfig())
+	if err != nil {
+		t.Fatalf("Could not encode config: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"level":"info"`) || strings.Contains(string(encoded), "Output") {
+		t.Errorf("Unexpected encoded config: %s", encoded)
+	}
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// ltsvEscaper escapes the characters LTSV values cannot contain
+var ltsvEscaper = strings.NewReplacer("\\", `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// LTSVFormatter formats logs as Labeled Tab-Separated Values (http://ltsv.org),
+// with one label:value pair per field, in the order of the entry. String
+// values are written unquoted with tabs and newlines escaped, and other values
+// as JSON.
+type LTSVFormatter struct{}
+
+// Format returns a writer that converts each JSON entry to an LTSV line.
+// Entries that are not JSON objects are written unchanged.
+func (f LTSVFormatter) Format(w io.Writer) io.Writer {
+	return ltsvWriter{out: w}
+}
+
+// ltsvWriter writes each JSON entry as an LTSV line
+type ltsvWriter struct {
+	out io.Writer
+}
+
+// Write implements io.Writer
+func (w ltsvWriter) Write(p []byte) (int, error) {
+	fields, err := parseEntry(p)
+	if err != nil {
+		return w.out.Write(p)
+	}
+	var buf bytes.Buffer
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte('\t')
+		}
+		buf.WriteString(ltsvEscaper.Replace(strings.ReplaceAll(f.key, ":", "_")))
+		buf.WriteByte(':')
+		buf.WriteString(ltsvEscaper.Replace(textValue(f.value)))
+	}
+	buf.WriteByte('\n')
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
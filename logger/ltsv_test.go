@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestLTSVFormat tests writing entries as LTSV lines
+func TestLTSVFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf, Format: FormatLTSV, ServiceName: "api", DisableTimestamp: true})
+
+	log.Info().
+		Str("path", "/a\tb").
+		Int("status", 200).
+		Dict("user", func(d *LogBuilder) { d.Str("id", "u1") }).
+		Msg("line one\nline two")
+
+	expected := "level:info\tservice:api\tpath:/a\\tb\tstatus:200\tuser:{\"id\":\"u1\"}\tmessage:line one\\nline two\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("Expected a single line per entry, got %q", buf.String())
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// typeMarshalers holds the marshal functions registered with RegisterMarshaler
+var (
+	typeMarshalersMu  sync.RWMutex
+	typeMarshalers    = map[reflect.Type]func(any) any{}
+	hasTypeMarshalers atomic.Bool
+)
+
+// RegisterMarshaler registers a function that converts values of type T
+// into the value written by AddField and WithFields, so custom types such as
+// UUIDs or decimals render consistently:
+//
+//	logger.RegisterMarshaler(func(id uuid.UUID) any { return id.String() })
+//
+// Registering a marshaler for a type replaces any previous one.
+func RegisterMarshaler[T any](fn func(T) any) {
+	typ := reflect.TypeFor[T]()
+	typeMarshalersMu.Lock()
+	defer typeMarshalersMu.Unlock()
+	typeMarshalers[typ] = func(v any) any { return fn(v.(T)) }
+	hasTypeMarshalers.Store(true)
+}
+
+// UnregisterMarshaler removes the marshal function registered for type T
+func UnregisterMarshaler[T any]() {
+	typ := reflect.TypeFor[T]()
+	typeMarshalersMu.Lock()
+	defer typeMarshalersMu.Unlock()
+	delete(typeMarshalers, typ)
+	hasTypeMarshalers.Store(len(typeMarshalers) > 0)
+}
+
+// marshalValue applies the registered marshal function for the type of v, if any
+func marshalValue(v any) any {
+	if v == nil || !hasTypeMarshalers.Load() {
+		return v
+	}
+	typeMarshalersMu.RLock()
+	fn, ok := typeMarshalers[reflect.TypeOf(v)]
+	typeMarshalersMu.RUnlock()
+	if !ok {
+		return v
+	}
+	return fn(v)
+}
+
+// LogObjectMarshaler is implemented by types that define their own structured
+// representation as a JSON object. It is zerolog's interface, so existing
+// implementations can be logged as is:
+//
+//	func (u User) MarshalZerologObject(e *zerolog.Event) {
+//		e.Str("id", u.ID).Str("role", u.Role)
+//	}
+type LogObjectMarshaler = zerolog.LogObjectMarshaler
+
+// LogArrayMarshaler is implemented by types that define their own structured
+// representation as a JSON array.
+type LogArrayMarshaler = zerolog.LogArrayMarshaler
+
+// Object adds a nested object field marshaled by obj to the log
+func (lb *LogBuilder) Object(key string, obj LogObjectMarshaler) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Object(lb.key(key), obj)
+	return lb
+}
+
+// EmbedObject adds the fields marshaled by obj at the top level of the log
+func (lb *LogBuilder) EmbedObject(obj LogObjectMarshaler) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.EmbedObject(obj)
+	return lb
+}
+
+// Array adds an array field marshaled by arr to the log
+func (lb *LogBuilder) Array(key string, arr LogArrayMarshaler) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.event.Array(lb.key(key), arr)
+	return lb
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// testUser is a domain type implementing LogObjectMarshaler
+type testUser struct {
+	ID   string
+	Role string
+}
+
+func (u testUser) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("id", u.ID).Str("role", u.Role)
+}
+
+// testUsers is a domain type implementing LogArrayMarshaler
+type testUsers []testUser
+
+func (us testUsers) MarshalZerologArray(a *zerolog.Array) {
+	for _, u := range us {
+		a.Object(u)
+	}
+}
+
+// TestObjectMarshalers tests logging domain types with their own marshalers
+func TestObjectMarshalers(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	user := testUser{ID: "u1", Role: "admin"}
+	log.Info().
+		Object("user", user).
+		Array("users", testUsers{user, {ID: "u2", Role: "viewer"}}).
+		Msg("users loaded")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"user":{"id":"u1","role":"admin"}`, "info")
+	assertLogContains(t, logData, `"users":[{"id":"u1","role":"admin"},{"id":"u2","role":"viewer"}]`, "")
+
+	buf.Reset()
+	log.Info().EmbedObject(user).Msg("user embedded")
+	assertLogContains(t, buf.String(), `"id":"u1","role":"admin"`, "info")
+
+	// AddField uses the marshaler as well
+	buf.Reset()
+	log.Info().AddField("user", user).Msg("generic field")
+	assertLogContains(t, buf.String(), `"user":{"id":"u1","role":"admin"}`, "info")
+}
+
+// testID is a custom type rendered through a registered marshaler
+type testID [4]byte
+
+// TestRegisterMarshaler tests the global type marshaler registry
+func TestRegisterMarshaler(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	RegisterMarshaler(func(id testID) any {
+		return fmt.Sprintf("%x", id[:])
+	})
+	defer UnregisterMarshaler[testID]()
+
+	id := testID{0xde, 0xad, 0xbe, 0xef}
+	log.Info().AddField("id", id).Msg("registered type")
+	assertLogContains(t, buf.String(), `"id":"deadbeef"`, "info")
+
+	buf.Reset()
+	log.WithFields(map[string]any{"id": id}).InfoMsg("context field")
+	assertLogContains(t, buf.String(), `"id":"deadbeef"`, "info")
+
+	UnregisterMarshaler[testID]()
+	buf.Reset()
+	log.Info().AddField("id", id).Msg("unregistered type")
+	assertLogContains(t, buf.String(), `"id":[222,173,190,239]`, "info")
+}
@@ -0,0 +1,28 @@
+//go:build easylogger_nodebug
+
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestNoDebugBuildTag tests that Trace and Debug are stripped with the easylogger_nodebug tag
+func TestNoDebugBuildTag(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: TraceLevel, Output: &buf})
+
+	log.Trace().Msg("trace message")
+	log.Debug().Str("key", "value").Msg("debug message")
+	log.TraceMsg("trace message")
+	log.DebugMsg("debug message")
+	if buf.Len() > 0 {
+		t.Errorf("Trace and Debug should be no-ops, got: %s", buf.String())
+	}
+	if log.Enabled(DebugLevel) {
+		t.Error("Debug level should not be enabled")
+	}
+
+	log.InfoMsg("info message")
+	assertLogContains(t, buf.String(), "info message", "info")
+}
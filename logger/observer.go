@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// ObservedEntry is a log entry recorded by an Observer
+type ObservedEntry struct {
+	// Level is the level of the entry, NoLevel if it has none
+	Level Level
+	// Message is the message of the entry
+	Message string
+	// Fields contains every other field of the entry except the timestamp
+	Fields map[string]any
+}
+
+// Observer records the entries of the loggers it is attached to in memory,
+// while they keep writing to their own output. It is meant for tests:
+//
+//	obs := logger.NewObserver()
+//	log = log.WithObserver(obs)
+//	...
+//	entries := obs.Entries()
+//
+// Entries are recorded as written to the output, after redaction and other
+// output processing, with the standard fields under their default keys.
+type Observer struct {
+	mu      sync.Mutex
+	entries []ObservedEntry
+}
+
+// NewObserver creates an empty Observer
+func NewObserver() *Observer {
+	return &Observer{}
+}
+
+// WithObserver returns a child logger whose entries are also recorded by the
+// observer, once processed. The logger itself and the loggers already derived
+// from it are not observed.
+func (l *Logger) WithObserver(o *Observer) *Logger {
+	w := l.writer.(*processWriter)
+	child := *l
+	child.writer = &processWriter{
+		out:        io.MultiWriter(w.out, zerologKeysWriter(l.cfg, o)),
+		processors: w.processors,
+	}
+	child.setBase(child.base.Output(child.writer))
+	return &child
+}
+
+// Entries returns a copy of the recorded entries
+func (o *Observer) Entries() []ObservedEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]ObservedEntry(nil), o.entries...)
+}
+
+// Len returns the number of recorded entries
+func (o *Observer) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// Reset discards the recorded entries
+func (o *Observer) Reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries = nil
+}
+
+// Write implements io.Writer, parsing and recording each JSON entry
+func (o *Observer) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(bytes.TrimSpace(p), []byte("\n")) {
+		entry, err := parseObservedEntry(line)
+		if err != nil {
+			return 0, err
+		}
+		o.mu.Lock()
+		o.entries = append(o.entries, entry)
+		o.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// parseObservedEntry decodes a JSON log entry
+func parseObservedEntry(line []byte) (ObservedEntry, error) {
+	fields := map[string]any{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return ObservedEntry{}, err
+	}
+
+	entry := ObservedEntry{Fields: fields, Level: NoLevel}
+	if level, ok := fields[zerolog.LevelFieldName].(string); ok {
+		entry.Level, _ = ParseLevel(level)
+	}
+	entry.Message, _ = fields[zerolog.MessageFieldName].(string)
+
+	delete(fields, zerolog.LevelFieldName)
+	delete(fields, zerolog.MessageFieldName)
+	delete(fields, zerolog.TimestampFieldName)
+	return entry, nil
+}
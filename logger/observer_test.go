@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestObserver tests recording entries while writing to the real output
+func TestObserver(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	obs := NewObserver()
+	log = log.WithObserver(obs)
+
+	log.Info().Str("user", "alice").Msg("user logged in")
+	log.WithFields(map[string]any{"request_id": "req-1"}).WarnMsg("slow request")
+
+	assertLogContains(t, buf.String(), "user logged in", "")
+	assertLogContains(t, buf.String(), "slow request", "")
+
+	entries := obs.Entries()
+	if obs.Len() != 2 || len(entries) != 2 {
+		t.Fatalf("Expected 2 observed entries, got %d", len(entries))
+	}
+	if entries[0].Level != InfoLevel || entries[0].Message != "user logged in" || entries[0].Fields["user"] != "alice" {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].Level != WarnLevel || entries[1].Fields["request_id"] != "req-1" {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+
+	obs.Reset()
+	if obs.Len() != 0 {
+		t.Error("Reset should discard the recorded entries")
+	}
+}
+
+// TestObserverProcessedEntries tests that the observer records the entries as
+// written, and only for the observed logger
+func TestObserverProcessedEntries(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{
+		Level:          InfoLevel,
+		Output:         &buf,
+		Pretty:         true,
+		RedactKeys:     []string{"password"},
+		LevelFieldName: "severity",
+	})
+	obs := NewObserver()
+	observed := log.WithObserver(obs)
+
+	log.Info().Msg("not observed")
+	observed.Info().Str("password", "hunter2").Msg("observed")
+
+	entries := obs.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 observed entry, got %+v", entries)
+	}
+	if entries[0].Level != InfoLevel || entries[0].Fields["password"] != RedactedValue {
+		t.Errorf("Expected the processed entry, got %+v", entries[0])
+	}
+	assertLogNotContains(t, buf.String(), "hunter2")
+}
@@ -22,6 +22,38 @@ func WithPrettyPrint(enabled bool) Option {
 	}
 }
 
+// WithFormat sets the output format, taking precedence over WithPrettyPrint.
+func WithFormat(format Format) Option {
+	return func(c *Config) {
+		c.Format = format
+	}
+}
+
+// WithPalette sets the level colors of the pretty format, such as
+// PaletteDeuteranopia or PaletteMonochrome.
+func WithPalette(palette Palette) Option {
+	return func(c *Config) {
+		c.Palette = palette
+	}
+}
+
+// WithLevelIcons prefixes the levels of the pretty format with the icons, such
+// as DefaultLevelIcons.
+func WithLevelIcons(icons map[Level]string) Option {
+	return func(c *Config) {
+		c.LevelIcons = icons
+	}
+}
+
+// WithCSVColumns sets the fields written by the CSV format, in order, and
+// whether the column names are written before the first entry.
+func WithCSVColumns(header bool, columns ...string) Option {
+	return func(c *Config) {
+		c.CSVColumns = columns
+		c.CSVHeader = header
+	}
+}
+
 // WithCaller enables or disables including the caller in log entries.
 func WithCaller(enabled bool) Option {
 	return func(c *Config) {
@@ -43,6 +75,239 @@ func WithTimeFormat(format string) Option {
 	}
 }
 
+// WithTimestamp enables or disables the timestamp field.
+func WithTimestamp(enabled bool) Option {
+	return func(c *Config) {
+		c.DisableTimestamp = !enabled
+	}
+}
+
+// WithTimestampFieldName sets the key used for the timestamp field.
+func WithTimestampFieldName(name string) Option {
+	return func(c *Config) {
+		c.TimestampFieldName = name
+	}
+}
+
+// WithFieldNames sets the keys used for the level, message, caller and service fields.
+// Empty values keep the default key.
+func WithFieldNames(level, message, caller, service string) Option {
+	return func(c *Config) {
+		c.LevelFieldName = level
+		c.MessageFieldName = message
+		c.CallerFieldName = caller
+		c.ServiceFieldName = service
+	}
+}
+
+// WithDurationFormat sets how durations are rendered.
+func WithDurationFormat(format string) Option {
+	return func(c *Config) {
+		c.DurationFormat = format
+	}
+}
+
+// WithErrorChain enables or disables expanding wrapped errors.
+func WithErrorChain(enabled bool) Option {
+	return func(c *Config) {
+		c.ErrorChain = enabled
+	}
+}
+
+// WithStackTrace enables or disables stack traces on error, fatal and panic entries.
+func WithStackTrace(enabled bool) Option {
+	return func(c *Config) {
+		c.StackTrace = enabled
+	}
+}
+
+// WithErrorMarshaler registers a function that adds structured fields for errors.
+func WithErrorMarshaler(marshaler ErrorMarshaler) Option {
+	return func(c *Config) {
+		c.ErrorMarshalers = append(c.ErrorMarshalers, marshaler)
+	}
+}
+
+// WithRedactKeys sets field keys whose values are redacted in every entry.
+func WithRedactKeys(keys ...string) Option {
+	return func(c *Config) {
+		c.RedactKeys = append(c.RedactKeys, keys...)
+	}
+}
+
+// WithScrubbers adds regex scrubbers applied to the message and string fields.
+func WithScrubbers(scrubbers ...Scrubber) Option {
+	return func(c *Config) {
+		c.Scrubbers = append(c.Scrubbers, scrubbers...)
+	}
+}
+
+// WithFieldAllowlist drops every field not listed, except the standard fields.
+func WithFieldAllowlist(keys ...string) Option {
+	return func(c *Config) {
+		c.FieldAllowlist = append(c.FieldAllowlist, keys...)
+	}
+}
+
+// WithFieldBlocklist drops the listed fields.
+func WithFieldBlocklist(keys ...string) Option {
+	return func(c *Config) {
+		c.FieldBlocklist = append(c.FieldBlocklist, keys...)
+	}
+}
+
+// WithPseudonymization replaces the values of the given keys with an HMAC hash keyed with secret.
+func WithPseudonymization(secret []byte, keys ...string) Option {
+	return func(c *Config) {
+		c.PseudonymizeSecret = secret
+		c.PseudonymizeKeys = append(c.PseudonymizeKeys, keys...)
+	}
+}
+
+// WithSecretDetection enables masking of likely secrets with a warning.
+func WithSecretDetection(enabled bool) Option {
+	return func(c *Config) {
+		c.DetectSecrets = enabled
+	}
+}
+
+// WithMaxFields caps the number of fields per entry.
+func WithMaxFields(max int) Option {
+	return func(c *Config) {
+		c.MaxFields = max
+	}
+}
+
+// WithSchema validates every entry against the schema.
+func WithSchema(schema *Schema) Option {
+	return func(c *Config) {
+		c.Schema = schema
+	}
+}
+
+// WithClock sets the clock used for timestamps.
+func WithClock(clock func() time.Time) Option {
+	return func(c *Config) {
+		c.Clock = clock
+	}
+}
+
+// WithDeterministic enables or disables deterministic output.
+func WithDeterministic(enabled bool) Option {
+	return func(c *Config) {
+		c.Deterministic = enabled
+	}
+}
+
+// WithCallerFunc enables or disables adding the function of the caller, as
+// pkg.Func, to entries with caller information.
+func WithCallerFunc(enabled bool) Option {
+	return func(c *Config) {
+		c.CallerFunc = enabled
+	}
+}
+
+// WithCallerTrimPrefix sets the prefix removed, with everything before it,
+// from caller paths, such as a module path or a build directory.
+func WithCallerTrimPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.CallerTrimPrefix = prefix
+	}
+}
+
+// WithGoroutineID enables or disables the goroutine ID field. Debugging only,
+// as it adds noticeable overhead to every entry.
+func WithGoroutineID(enabled bool) Option {
+	return func(c *Config) {
+		c.GoroutineID = enabled
+	}
+}
+
+// WithKeyCollision sets how WithFields handles keys already in the context.
+func WithKeyCollision(policy KeyCollisionPolicy) Option {
+	return func(c *Config) {
+		c.KeyCollision = policy
+	}
+}
+
+// WithExitFunc sets the function called to exit after a fatal log.
+func WithExitFunc(fn func(code int)) Option {
+	return func(c *Config) {
+		c.ExitFunc = fn
+	}
+}
+
+// WithFatalHook sets a function called before exiting after a fatal log.
+func WithFatalHook(fn func()) Option {
+	return func(c *Config) {
+		c.FatalHook = fn
+	}
+}
+
+// WithHealthCheck adds a sink to the Health report.
+func WithHealthCheck(name string, hc HealthChecker) Option {
+	return func(c *Config) {
+		if c.HealthChecks == nil {
+			c.HealthChecks = make(map[string]HealthChecker)
+		}
+		c.HealthChecks[name] = hc
+	}
+}
+
+// WithSigning enables the tamper-evident signature chain keyed with key.
+func WithSigning(key []byte) Option {
+	return func(c *Config) {
+		c.SigningKey = key
+	}
+}
+
+// WithProgressInterval sets the minimum time between progress entries.
+func WithProgressInterval(d time.Duration) Option {
+	return func(c *Config) {
+		c.ProgressInterval = d
+	}
+}
+
+// WithErrorClassifier registers a function that chooses the level of errors logged with ErrOr.
+func WithErrorClassifier(classifier ErrorClassifier) Option {
+	return func(c *Config) {
+		c.ErrorClassifiers = append(c.ErrorClassifiers, classifier)
+	}
+}
+
+// WithErrorCode sets the code logged for errors matching err.
+func WithErrorCode(err error, code string) Option {
+	return func(c *Config) {
+		if c.ErrorCodes == nil {
+			c.ErrorCodes = make(map[error]string)
+		}
+		c.ErrorCodes[err] = code
+	}
+}
+
+// WithSyslogSeverity enables or disables the numeric syslog severity field.
+func WithSyslogSeverity(enabled bool) Option {
+	return func(c *Config) {
+		c.SyslogSeverity = enabled
+	}
+}
+
+// WithTenantOutput sets the function choosing the output of each tenant logger.
+func WithTenantOutput(output func(tenant string) io.Writer) Option {
+	return func(c *Config) {
+		c.TenantOutput = output
+	}
+}
+
+// WithQuota caps the entries per minute for each value of the key field, such
+// as TenantFieldName, so a single noisy tenant can't drown the shared output.
+func WithQuota(perMinute int, key string) Option {
+	return func(c *Config) {
+		c.Quota = perMinute
+		c.QuotaKey = key
+	}
+}
+
 // NewWithOptions creates a new logger with the provided options.
 func NewWithOptions(opts ...Option) *Logger {
 	cfg := DefaultConfig()
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Palette is the set of level colors used by the pretty formatters
+type Palette string
+
+// Built-in palettes. Custom levels keep the color they were registered with in
+// every palette but PaletteMonochrome.
+const (
+	// PaletteDefault colors the levels red, yellow, green and blue
+	PaletteDefault Palette = "default"
+	// PaletteDeuteranopia uses blue and orange hues, distinguishable with
+	// red-green color blindness
+	PaletteDeuteranopia Palette = "deuteranopia"
+	// PaletteHighContrast uses bold, bright colors and inverted backgrounds for
+	// the most severe levels
+	PaletteHighContrast Palette = "high-contrast"
+	// PaletteMonochrome uses no colors, only dim, bold, underlined and inverted text
+	PaletteMonochrome Palette = "monochrome"
+)
+
+// palettes maps each palette but the default one to the ANSI SGR parameters of
+// the built-in levels. Levels missing from a palette are not styled.
+var palettes = map[Palette]map[Level]string{
+	PaletteDeuteranopia: {
+		TraceLevel:    "38;5;244",
+		DebugLevel:    "38;5;117",
+		InfoLevel:     "38;5;33",
+		NoticeLevel:   "38;5;81",
+		WarnLevel:     "38;5;214",
+		ErrorLevel:    "1;38;5;202",
+		CriticalLevel: "1;38;5;175",
+		FatalLevel:    "1;7;38;5;202",
+		PanicLevel:    "1;7;38;5;202",
+	},
+	PaletteHighContrast: {
+		TraceLevel:    "97",
+		DebugLevel:    "1;96",
+		InfoLevel:     "1;92",
+		NoticeLevel:   "1;96",
+		WarnLevel:     "1;93",
+		ErrorLevel:    "1;91",
+		CriticalLevel: "1;97;45",
+		FatalLevel:    "1;97;41",
+		PanicLevel:    "1;97;41",
+	},
+	PaletteMonochrome: {
+		TraceLevel:    "2",
+		DebugLevel:    "2",
+		NoticeLevel:   "1",
+		WarnLevel:     "1",
+		ErrorLevel:    "1;4",
+		CriticalLevel: "1;4",
+		FatalLevel:    "1;7",
+		PanicLevel:    "1;7",
+	},
+}
+
+// DefaultLevelIcons are level glyphs for Config.LevelIcons, making levels
+// faster to scan in busy terminals
+var DefaultLevelIcons = map[Level]string{
+	TraceLevel:    "·",
+	DebugLevel:    "🐛",
+	InfoLevel:     "ℹ",
+	NoticeLevel:   "➤",
+	WarnLevel:     "⚠",
+	ErrorLevel:    "✖",
+	CriticalLevel: "‼",
+	FatalLevel:    "☠",
+	PanicLevel:    "💥",
+}
+
+// ParsePalette parses a palette name, case-insensitively
+func ParsePalette(s string) (Palette, error) {
+	p := Palette(strings.ToLower(s))
+	if _, ok := palettes[p]; ok || p == PaletteDefault {
+		return p, nil
+	}
+	return "", fmt.Errorf("invalid palette: %s", s)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so palettes are validated
+// when decoded from configuration files
+func (p *Palette) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = ""
+		return nil
+	}
+	parsed, err := ParsePalette(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// style returns the ANSI SGR parameters of the level in the palette, empty if
+// the level is not styled
+func (p Palette) style(level Level, info levelInfo) string {
+	styles, ok := palettes[p]
+	if !ok {
+		if info.color == 0 {
+			return ""
+		}
+		return fmt.Sprint(info.color)
+	}
+	if s, ok := styles[level]; ok {
+		return s
+	}
+	if _, builtin := levels[level]; builtin || p == PaletteMonochrome || info.color == 0 {
+		return ""
+	}
+	return fmt.Sprint(info.color)
+}
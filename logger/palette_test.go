@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestPalettes tests the level colors of the built-in palettes
+func TestPalettes(t *testing.T) {
+	tests := []struct {
+		palette  Palette
+		level    string
+		expected string
+	}{
+		{"", "error", "\x1b[31mERR\x1b[0m"},
+		{PaletteDefault, "warn", "\x1b[33mWRN\x1b[0m"},
+		{PaletteDeuteranopia, "warn", "\x1b[38;5;214mWRN\x1b[0m"},
+		{PaletteHighContrast, "fatal", "\x1b[1;97;41mFTL\x1b[0m"},
+		{PaletteMonochrome, "error", "\x1b[1;4mERR\x1b[0m"},
+		{PaletteMonochrome, "info", "INF"},
+	}
+	for _, tt := range tests {
+		if got := formatLevel(false, tt.palette, nil)(tt.level); got != tt.expected {
+			t.Errorf("%s %s: expected %q, got %q", tt.palette, tt.level, tt.expected, got)
+		}
+	}
+	if got := formatLevel(true, PaletteHighContrast, nil)("error"); got != "ERR" {
+		t.Errorf("Expected no colors with noColor, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithPrettyPrint(true), WithPalette(PaletteDeuteranopia))
+	log.Error().Msg("failed")
+	if !strings.Contains(buf.String(), "\x1b[1;38;5;202mERR") {
+		t.Errorf("Expected the palette color in the pretty output, got %q", buf.String())
+	}
+}
+
+// TestParsePalette tests parsing palette names
+func TestParsePalette(t *testing.T) {
+	for _, name := range []string{"default", "deuteranopia", "High-Contrast", "monochrome"} {
+		if _, err := ParsePalette(name); err != nil {
+			t.Errorf("ParsePalette(%q) failed: %v", name, err)
+		}
+	}
+	if _, err := ParsePalette("neon"); err == nil {
+		t.Error("Expected an error for an unknown palette")
+	}
+
+	t.Setenv(EnvLogPalette, "monochrome")
+	if log := NewFromEnv(); log.cfg.Palette != PaletteMonochrome {
+		t.Errorf("Expected the palette from the environment, got %q", log.cfg.Palette)
+	}
+}
+
+// TestLevelIcons tests prefixing the pretty levels with icons
+func TestLevelIcons(t *testing.T) {
+	icons := map[Level]string{WarnLevel: "!!"}
+	if got := formatLevel(true, "", DefaultLevelIcons)("error"); got != "✖ ERR" {
+		t.Errorf("Expected the default error icon, got %q", got)
+	}
+	if got := formatLevel(false, "", icons)("warn"); got != "\x1b[33m!! WRN\x1b[0m" {
+		t.Errorf("Expected the custom icon inside the color, got %q", got)
+	}
+	if got := formatLevel(true, "", icons)("info"); got != "INF" {
+		t.Errorf("Expected no icon for a level without one, got %q", got)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"level_icons":{"error":"E","warning":"W"}}`), &cfg); err != nil {
+		t.Fatalf("Could not decode the icons: %v", err)
+	}
+	if cfg.LevelIcons[ErrorLevel] != "E" || cfg.LevelIcons[WarnLevel] != "W" {
+		t.Errorf("Expected icons keyed by level, got %v", cfg.LevelIcons)
+	}
+
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithPrettyPrint(true), WithLevelIcons(DefaultLevelIcons))
+	log.Warn().Msg("slow")
+	if !strings.Contains(buf.String(), "⚠ WRN") {
+		t.Errorf("Expected the icon in the pretty output, got %q", buf.String())
+	}
+}
@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// PanicFieldName is the key used for the recovered panic value
+const PanicFieldName = "panic"
+
+// PanicError is the value Panic().Msg panics with. It carries the fields of
+// the entry, including the context fields, so recover sites can log them
+// again; RecoverAndLog and friends do so automatically.
+type PanicError struct {
+	// Message is the message of the entry
+	Message string
+	// Fields are the fields of the entry, without the standard fields and the
+	// fields derived from Err or added when recovering, such as the stack
+	Fields map[string]any
+	// Err is the error added with WithError, if any
+	Err error
+}
+
+// Error implements error
+func (e *PanicError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the error added with WithError
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
+// captureFieldName marks the entry of a Panic log with the ID of its capture.
+// The processWriter removes it before the processors run.
+const captureFieldName = "\x00capture"
+
+// entryCapture records the entry of a Panic log as written to the output,
+// after the processors, so the panic value carries no more than the log
+type entryCapture struct {
+	w  *processWriter
+	id string
+	// written is set when the entry reached the writer, even if a processor
+	// dropped it
+	written bool
+	entry   []byte
+}
+
+// release unregisters the capture, if the entry never reached the writer
+func (c *entryCapture) release() {
+	c.w.take(c.id)
+}
+
+// captures holds the captures of the Panic entries being written
+type captures struct {
+	mu      sync.Mutex
+	next    uint64
+	pending map[string]*entryCapture
+	// count is the number of pending captures, read without the lock
+	count atomic.Int32
+}
+
+// capture registers a capture for the entry of event, marking the entry with
+// its ID
+func (w *processWriter) capture(event *zerolog.Event) *entryCapture {
+	w.captures.mu.Lock()
+	defer w.captures.mu.Unlock()
+	w.captures.next++
+	c := &entryCapture{w: w, id: strconv.FormatUint(w.captures.next, 10)}
+	if w.captures.pending == nil {
+		w.captures.pending = make(map[string]*entryCapture)
+	}
+	w.captures.pending[c.id] = c
+	w.captures.count.Add(1)
+	event.Str(captureFieldName, c.id)
+	return c
+}
+
+// take unregisters and returns the capture with the ID, nil if there is none
+func (w *processWriter) take(id string) *entryCapture {
+	w.captures.mu.Lock()
+	defer w.captures.mu.Unlock()
+	c, ok := w.captures.pending[id]
+	if ok {
+		delete(w.captures.pending, id)
+		w.captures.count.Add(-1)
+	}
+	return c
+}
+
+// takeMarked removes the capture marker from the fields of an entry and
+// returns its capture, nil if the entry is not marked
+func (w *processWriter) takeMarked(fields []entryField) ([]entryField, *entryCapture) {
+	for i, f := range fields {
+		if f.key == captureFieldName {
+			id, _ := stringValue(f.value)
+			return slices.Delete(fields, i, i+1), w.take(id)
+		}
+	}
+	return fields, nil
+}
+
+// newPanicError builds the panic value from the serialized entry
+func (l *Logger) newPanicError(msg string, err error, entry []byte) *PanicError {
+	pe := &PanicError{Message: msg, Fields: map[string]any{}, Err: err}
+	fields, perr := parseEntry(entry)
+	if perr != nil {
+		return pe
+	}
+	standard := append(standardFieldNames(l.cfg),
+		zerolog.ErrorFieldName, ErrorChainFieldName, ErrorRootFieldName, ErrorCodeFieldName,
+		zerolog.ErrorStackFieldName, GoroutineFieldName, CallerFuncFieldName)
+	for _, f := range fields {
+		if slices.Contains(standard, f.key) {
+			continue
+		}
+		var v any
+		if json.Unmarshal(f.value, &v) == nil {
+			pe.Fields[f.key] = v
+		}
+	}
+	return pe
+}
+
+// RecoverAndLog recovers from a panic and logs it at panic level with the
+// stack trace and goroutine ID. It must be called directly with defer:
+//
+//	defer logger.RecoverAndLog(log)
+func RecoverAndLog(l *Logger) {
+	if r := recover(); r != nil {
+		logPanic(l, r)
+	}
+}
+
+// RecoverAndRepanic logs a panic like RecoverAndLog and then panics again with
+// the same value, so the panic still propagates after being logged.
+func RecoverAndRepanic(l *Logger) {
+	if r := recover(); r != nil {
+		logPanic(l, r)
+		panic(r)
+	}
+}
+
+// RecoverToError logs a panic like RecoverAndLog and stores it in errp as an
+// error, which is useful for HTTP handlers and workers that return errors:
+//
+//	func work() (err error) {
+//		defer logger.RecoverToError(log, &err)
+//		...
+//	}
+func RecoverToError(l *Logger, errp *error) {
+	if r := recover(); r != nil {
+		logPanic(l, r)
+		if errp != nil {
+			*errp = panicError(r)
+		}
+	}
+}
+
+// logPanic writes the recovered value at panic level without panicking again.
+// The recovered keys are already prefixed and the panic and goroutine keys
+// are standard keys, so they are written to the event without the prefix.
+func logPanic(l *Logger, r any) {
+	lb := l.newLogBuilder(l.zl.WithLevel(zerolog.PanicLevel))
+	if pe, ok := r.(*PanicError); ok {
+		keys := make([]string, 0, len(pe.Fields))
+		for k := range pe.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// Context fields shared with this logger are already written
+			if fieldIndex(l.fields, k) < 0 {
+				lb.event.Interface(k, marshalValue(pe.Fields[k]))
+			}
+		}
+		lb.event.Str(PanicFieldName, pe.Message)
+		if pe.Err != nil {
+			lb.WithError(pe.Err)
+		}
+	} else if err, ok := r.(error); ok {
+		lb.WithError(err)
+	} else {
+		lb.event.Interface(PanicFieldName, marshalValue(r))
+	}
+	lb.event.Uint64(GoroutineFieldName, goroutineID())
+	lb.Stack().Msg("recovered from panic")
+}
+
+// panicError converts a recovered value into an error
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return fmt.Errorf("panic: %w", err)
+	}
+	return fmt.Errorf("panic: %v", r)
+}
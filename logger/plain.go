@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultPlainMessageWidth is the width the message is padded to by a
+// PlainFormatter without MessageWidth
+const DefaultPlainMessageWidth = 40
+
+// plainLevelWidth is the width the level is padded to, the longest built-in level name
+const plainLevelWidth = 8
+
+// PlainFormatter formats logs as single lines without colors, for CI logs and
+// kubectl logs: the timestamp, the level and the message in aligned columns,
+// followed by the other fields as key=value pairs:
+//
+//	2024-01-01T12:00:00Z INFO     request handled                          status=200 path=/users
+type PlainFormatter struct {
+	// MessageWidth is the width the message is padded to. Defaults to
+	// DefaultPlainMessageWidth, negative disables the padding
+	MessageWidth int
+}
+
+// Format returns a writer that converts each JSON entry to a plain line.
+// Entries that are not JSON objects are written unchanged.
+func (f PlainFormatter) Format(w io.Writer) io.Writer {
+	width := f.MessageWidth
+	if width == 0 {
+		width = DefaultPlainMessageWidth
+	}
+	return plainWriter{out: w, messageWidth: max(width, 0)}
+}
+
+// plainWriter writes each JSON entry as a plain line
+type plainWriter struct {
+	out          io.Writer
+	messageWidth int
+}
+
+// Write implements io.Writer
+func (w plainWriter) Write(p []byte) (int, error) {
+	fields, err := parseEntry(p)
+	if err != nil {
+		return w.out.Write(p)
+	}
+	var timestamp, level, message string
+	rest := make([]entryField, 0, len(fields))
+	for _, f := range fields {
+		switch f.key {
+		case zerolog.TimestampFieldName:
+			timestamp = textValue(f.value)
+		case zerolog.LevelFieldName:
+			level = textValue(f.value)
+		case zerolog.MessageFieldName:
+			message = textValue(f.value)
+		default:
+			rest = append(rest, f)
+		}
+	}
+
+	var buf bytes.Buffer
+	if timestamp != "" {
+		buf.WriteString(timestamp)
+		buf.WriteByte(' ')
+	}
+	fmt.Fprintf(&buf, "%-*s %-*s", plainLevelWidth, strings.ToUpper(level), w.messageWidth, escapeLine(message))
+	for _, f := range rest {
+		buf.WriteByte(' ')
+		buf.WriteString(f.key)
+		buf.WriteByte('=')
+		buf.WriteString(plainValue(textValue(f.value)))
+	}
+	line := bytes.TrimRight(buf.Bytes(), " ")
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// escapeLine escapes the line breaks of s, keeping the entry on one line
+func escapeLine(s string) string {
+	return strings.NewReplacer("\n", `\n`, "\r", `\r`).Replace(s)
+}
+
+// plainValue quotes the value if it is empty or contains spaces, quotes, equal
+// signs or control characters
+func plainValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \"=\t\n\r") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestPlainFormat tests writing entries as aligned key=value lines
+func TestPlainFormat(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Level: InfoLevel, Output: &buf, Format: FormatPlain, ServiceName: "api", DisableTimestamp: true})
+
+	log.Info().Int("status", 200).Str("path", "/users").Msg("request handled")
+	log.Warn().Str("reason", "too slow").Str("empty", "").Msg("retry\nscheduled")
+
+	expected := "INFO     request handled                          service=api status=200 path=/users\n" +
+		"WARN     retry\\nscheduled                         service=api reason=\"too slow\" empty=\"\"\n"
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%q\ngot:\n%q", expected, buf.String())
+	}
+}
+
+// TestPlainFormatFromEnv tests selecting the plain format with LOG_FORMAT
+func TestPlainFormatFromEnv(t *testing.T) {
+	t.Setenv(EnvLogFormat, "plain")
+
+	log := NewFromEnv()
+	if log.cfg.format() != FormatPlain {
+		t.Errorf("Expected the plain format, got %q", log.cfg.format())
+	}
+}
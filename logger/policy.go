@@ -0,0 +1,82 @@
+package logger
+
+// allowFields returns a processor that drops every top-level field not in
+// keys. The standard fields (time, level, message, caller and service) are
+// always kept.
+func allowFields(keys []string, cfg Config) entryProcessor {
+	allowed := make(map[string]struct{}, len(keys)+5)
+	for _, k := range keys {
+		allowed[k] = struct{}{}
+	}
+	for _, k := range standardFieldNames(cfg) {
+		allowed[k] = struct{}{}
+	}
+	return func(fields []entryField) []entryField {
+		kept := fields[:0]
+		for _, f := range fields {
+			if _, ok := allowed[f.key]; ok {
+				kept = append(kept, f)
+			}
+		}
+		return kept
+	}
+}
+
+// blockFields returns a processor that drops the top-level fields in keys
+func blockFields(keys []string) entryProcessor {
+	blocked := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		blocked[k] = struct{}{}
+	}
+	return func(fields []entryField) []entryField {
+		kept := fields[:0]
+		for _, f := range fields {
+			if _, ok := blocked[f.key]; !ok {
+				kept = append(kept, f)
+			}
+		}
+		return kept
+	}
+}
+
+// standardFieldNames returns the configured keys of the standard fields
+func standardFieldNames(cfg Config) []string {
+	return []string{
+		fieldName(cfg.TimestampFieldName, DefaultTimestampFieldName),
+		fieldName(cfg.LevelFieldName, DefaultLevelFieldName),
+		fieldName(cfg.MessageFieldName, DefaultMessageFieldName),
+		fieldName(cfg.CallerFieldName, DefaultCallerFieldName),
+		fieldName(cfg.ServiceFieldName, DefaultServiceFieldName),
+	}
+}
+
+// ExtraFieldsFieldName is the key counting the fields dropped by Config.MaxFields
+const ExtraFieldsFieldName = "_extra_fields"
+
+// capFields returns a processor that keeps at most max fields per entry, not
+// counting the standard fields. The number of dropped fields is written to
+// ExtraFieldsFieldName.
+func capFields(max int, cfg Config) entryProcessor {
+	standard := make(map[string]struct{}, 5)
+	for _, k := range standardFieldNames(cfg) {
+		standard[k] = struct{}{}
+	}
+	return func(fields []entryField) []entryField {
+		kept := fields[:0]
+		count, extra := 0, 0
+		for _, f := range fields {
+			if _, ok := standard[f.key]; !ok {
+				if count >= max {
+					extra++
+					continue
+				}
+				count++
+			}
+			kept = append(kept, f)
+		}
+		if extra > 0 {
+			kept = append(kept, entryField{key: ExtraFieldsFieldName, value: mustMarshal(extra)})
+		}
+		return kept
+	}
+}
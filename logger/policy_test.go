@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestFieldPolicies tests the field allowlist and blocklist
+func TestFieldPolicies(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithFieldAllowlist("request_id", "status"),
+	)
+	log.Info().
+		Str("request_id", "req-1").
+		Int("status", 200).
+		Str("user_email", "a@b.c").
+		Msg("request handled")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"request_id":"req-1"`, "info")
+	assertLogContains(t, logData, `"status":200`, "")
+	assertLogContains(t, logData, `"service":"UNKNOWN-SERVICE"`, "")
+	assertLogContains(t, logData, `"caller"`, "")
+	assertLogContains(t, logData, `"message":"request handled"`, "")
+	assertLogNotContains(t, logData, "user_email")
+
+	buf.Reset()
+	log = NewWithOptions(
+		WithOutput(&buf),
+		WithFieldBlocklist("user_email"),
+	)
+	log.Info().
+		Str("request_id", "req-1").
+		Str("user_email", "a@b.c").
+		Msg("request handled")
+
+	logData = buf.String()
+	assertLogContains(t, logData, `"request_id":"req-1"`, "info")
+	assertLogNotContains(t, logData, "user_email")
+}
+
+// TestMaxFields tests capping the number of fields per entry
+func TestMaxFields(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithMaxFields(2),
+	)
+
+	lb := log.Info()
+	for i := 0; i < 5; i++ {
+		lb.Int(fmt.Sprintf("field%d", i), i)
+	}
+	lb.Msg("many fields")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"field0":0,"field1":1`, "info")
+	assertLogContains(t, logData, `"_extra_fields":3`, "")
+	assertLogContains(t, logData, `"message":"many fields"`, "")
+	assertLogNotContains(t, logData, "field2")
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultProgressInterval is the minimum time between progress entries if Config.ProgressInterval is zero
+const DefaultProgressInterval = 10 * time.Second
+
+// ProgressMessage is the message of progress entries
+const ProgressMessage = "progress"
+
+// Keys used by Progress
+const (
+	ProgressTaskFieldName    = "task"
+	ProgressDoneFieldName    = "done"
+	ProgressTotalFieldName   = "total"
+	ProgressPercentFieldName = "percent"
+	ProgressElapsedFieldName = "elapsed"
+	ProgressETAFieldName     = "eta"
+)
+
+// Progress logs the progress of a long-running task at info level, at most
+// once per Config.ProgressInterval and once more when the task completes:
+//
+//	p := log.Progress("backfill", int64(len(rows)))
+//	for _, row := range rows {
+//		process(row)
+//		p.Add(1)
+//	}
+//
+// It is safe for concurrent use.
+type Progress struct {
+	logger   *Logger
+	task     string
+	total    int64
+	interval time.Duration
+
+	mu       sync.Mutex
+	done     int64
+	start    time.Time
+	lastLog  time.Time
+	finished bool
+}
+
+// Progress starts tracking a task of total units. A total of zero or less
+// means the total is unknown, and only the units done are logged.
+func (l *Logger) Progress(task string, total int64) *Progress {
+	interval := l.cfg.ProgressInterval
+	if interval <= 0 {
+		interval = DefaultProgressInterval
+	}
+	p := &Progress{logger: l, task: task, total: total, interval: interval}
+	p.start = l.clock()
+	p.lastLog = p.start
+	return p
+}
+
+// Add records n more units done, and logs the progress if the interval has
+// elapsed since the last entry or the task is complete
+func (p *Progress) Add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	now := p.logger.clock()
+	complete := p.total > 0 && p.done >= p.total
+	if complete && !p.finished {
+		p.finished = true
+	} else if now.Sub(p.lastLog) < p.interval {
+		return
+	}
+	p.lastLog = now
+	p.log(now)
+}
+
+// log writes a progress entry
+func (p *Progress) log(now time.Time) {
+	lb := p.logger.Info()
+	if lb == nil {
+		return
+	}
+	elapsed := now.Sub(p.start)
+	lb.Str(ProgressTaskFieldName, p.task).
+		Int64(ProgressDoneFieldName, p.done).
+		Dur(ProgressElapsedFieldName, elapsed)
+	if p.total > 0 {
+		lb.Int64(ProgressTotalFieldName, p.total).
+			Float64(ProgressPercentFieldName, float64(min(p.done, p.total))*100/float64(p.total))
+		if p.done > 0 {
+			remaining := max(p.total-p.done, 0)
+			lb.Dur(ProgressETAFieldName, time.Duration(float64(elapsed)*float64(remaining)/float64(p.done)))
+		}
+	}
+	lb.Msg(ProgressMessage)
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestProgress tests the throttling and fields of progress entries
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	log := NewWithOptions(WithOutput(&buf), WithProgressInterval(time.Minute), WithClock(func() time.Time { return now }))
+
+	p := log.Progress("backfill", 100)
+
+	now = now.Add(30 * time.Second)
+	p.Add(10)
+	if buf.Len() > 0 {
+		t.Fatalf("Expected no entry before the interval, got: %s", buf.String())
+	}
+
+	now = now.Add(30 * time.Second)
+	p.Add(10)
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if entry["message"] != ProgressMessage || entry["task"] != "backfill" {
+		t.Errorf("Unexpected entry: %s", buf.String())
+	}
+	if entry["done"] != 20.0 || entry["total"] != 100.0 || entry["percent"] != 20.0 {
+		t.Errorf("Unexpected progress: %s", buf.String())
+	}
+	// 20 units in a minute, 80 left
+	if entry["elapsed"] != 60000.0 || entry["eta"] != 240000.0 {
+		t.Errorf("Unexpected elapsed or ETA: %s", buf.String())
+	}
+
+	// The completion is logged right away, once
+	buf.Reset()
+	now = now.Add(time.Second)
+	p.Add(80)
+	p.Add(1)
+	if n := strings.Count(buf.String(), "\n"); n != 1 {
+		t.Fatalf("Expected one completion entry, got %d: %s", n, buf.String())
+	}
+	assertLogContains(t, buf.String(), `"percent":100`, "info")
+	assertLogContains(t, buf.String(), `"eta":0`, "info")
+}
+
+// TestProgressUnknownTotal tests progress without a known total
+func TestProgressUnknownTotal(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithProgressInterval(time.Nanosecond))
+
+	p := log.Progress("scan", 0)
+	time.Sleep(time.Millisecond)
+	p.Add(5)
+	assertLogContains(t, buf.String(), `"done":5`, "info")
+	assertLogNotContains(t, buf.String(), "percent")
+	assertLogNotContains(t, buf.String(), "eta")
+}
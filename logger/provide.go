@@ -0,0 +1,13 @@
+package logger
+
+// ProvideLogger creates a logger for dependency injection frameworks. The
+// cleanup function closes it, so it is a wire provider as is:
+//
+//	var LogSet = wire.NewSet(logger.ProvideLogger, loadLogConfig)
+//
+// The fx modules and wire provider sets are in the logger/fx and logger/wire
+// modules, so the applications not using them do not depend on fx or wire.
+func ProvideLogger(cfg Config) (*Logger, func()) {
+	l := New(cfg)
+	return l, func() { l.Close() }
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestProvideLogger tests the dependency injection constructor and its cleanup
+func TestProvideLogger(t *testing.T) {
+	file, err := OpenFile(filepath.Join(t.TempDir(), "app.log"))
+	if err != nil {
+		t.Fatalf("OpenFile failed: %v", err)
+	}
+	log, cleanup := ProvideLogger(Config{Level: InfoLevel, Output: file})
+
+	log.Info().Msg("running")
+	cleanup()
+	if _, err := file.Write([]byte("{}\n")); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected the cleanup to close the output, got %v", err)
+	}
+
+	// The standard error is never closed
+	if err := New(DefaultConfig()).Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// QuotaWindow is the period over which Config.Quota entries are allowed per key
+const QuotaWindow = time.Minute
+
+// SuppressedFieldName is the key of the number of entries dropped by the quota
+// in the suppression summary
+const SuppressedFieldName = "suppressed"
+
+// quotaWindow counts the entries of a key in the current window
+type quotaWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// quota tracks the entries of every key value and reports the suppressed ones
+type quota struct {
+	limit int
+	key   string
+	now   func() time.Time
+	meta  zerolog.Logger
+
+	mu      sync.Mutex
+	windows map[string]*quotaWindow
+}
+
+// enforceQuota returns a processor dropping the entries above cfg.Quota per
+// QuotaWindow for each value of the quota key. When a window with suppressed
+// entries is over, a warning summarizing them is written to meta on the next
+// entry. Entries without the key are not limited.
+func enforceQuota(cfg Config, meta zerolog.Logger) entryProcessor {
+	q := &quota{
+		limit:   cfg.Quota,
+		key:     fieldName(cfg.QuotaKey, TenantFieldName),
+		now:     cfg.Clock,
+		meta:    meta,
+		windows: make(map[string]*quotaWindow),
+	}
+	if q.now == nil {
+		q.now = time.Now
+	}
+	return q.process
+}
+
+// process counts the entry against its key and drops it if the quota is exceeded
+func (q *quota) process(fields []entryField) []entryField {
+	value, ok := q.value(fields)
+	now := q.now()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.summarize(now)
+	if !ok {
+		return fields
+	}
+	w := q.windows[value]
+	if w == nil {
+		w = &quotaWindow{start: now}
+		q.windows[value] = w
+	}
+	if w.count >= q.limit {
+		w.suppressed++
+		return nil
+	}
+	w.count++
+	return fields
+}
+
+// value returns the quota key of the entry
+func (q *quota) value(fields []entryField) (string, bool) {
+	for _, f := range fields {
+		if f.key == q.key {
+			if s, ok := stringValue(f.value); ok {
+				return s, true
+			}
+			return string(f.value), true
+		}
+	}
+	return "", false
+}
+
+// summarize ends the windows older than QuotaWindow, writing a warning for
+// every key whose entries were suppressed
+func (q *quota) summarize(now time.Time) {
+	var ended []string
+	for value, w := range q.windows {
+		if now.Sub(w.start) >= QuotaWindow {
+			ended = append(ended, value)
+		}
+	}
+	sort.Strings(ended)
+	for _, value := range ended {
+		w := q.windows[value]
+		delete(q.windows, value)
+		if w.suppressed == 0 {
+			continue
+		}
+		q.meta.Warn().
+			Str(q.key, value).
+			Int(SuppressedFieldName, w.suppressed).
+			Int("quota", q.limit).
+			Dur("window", QuotaWindow).
+			Msg("log quota exceeded, entries suppressed")
+	}
+}
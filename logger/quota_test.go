@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestQuota tests dropping the entries above the quota and summarizing them
+func TestQuota(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithQuota(2, ""),
+		WithClock(func() time.Time { return now }),
+	)
+
+	noisy, quiet := log.ForTenant("noisy"), log.ForTenant("quiet")
+	for i := 0; i < 5; i++ {
+		noisy.Info().Msg("noisy entry")
+	}
+	quiet.Info().Msg("quiet entry")
+	log.Info().Msg("untracked entry")
+	log.Info().Msg("untracked entry")
+	log.Info().Msg("untracked entry")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 2 noisy, 1 quiet and 3 untracked entries, got %d: %s", len(lines), buf.String())
+	}
+
+	// The summary is written with the first entry after the window
+	buf.Reset()
+	now = now.Add(QuotaWindow)
+	noisy.Info().Msg("noisy entry")
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected the summary and the entry, got: %s", buf.String())
+	}
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &summary); err != nil {
+		t.Fatalf("Could not parse summary as JSON: %v", err)
+	}
+	if summary["level"] != "warn" || summary[TenantFieldName] != "noisy" {
+		t.Errorf("Unexpected summary: %s", lines[0])
+	}
+	if summary[SuppressedFieldName] != float64(3) || summary["quota"] != float64(2) {
+		t.Errorf("Expected 3 suppressed entries with a quota of 2, got: %s", lines[0])
+	}
+	assertLogContains(t, lines[1], "noisy entry", "info")
+}
+
+// TestQuotaKey tests applying the quota to another field
+func TestQuotaKey(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithQuota(1, "user"))
+
+	log.Info().Int("user", 7).Msg("first")
+	log.Info().Int("user", 7).Msg("second")
+	log.Info().Int("user", 8).Msg("other user")
+
+	assertLogNotContains(t, buf.String(), "second")
+	if !strings.Contains(buf.String(), "first") || !strings.Contains(buf.String(), "other user") {
+		t.Errorf("Expected the first entry of each user, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted fields
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeys is a set of commonly sensitive keys that can be passed to WithRedactKeys
+var DefaultRedactKeys = []string{"password", "passwd", "secret", "token", "access_token", "refresh_token", "api_key", "authorization", "cookie", "ssn"}
+
+// redactedJSON is RedactedValue encoded as a JSON string
+var redactedJSON = mustMarshal(RedactedValue)
+
+// redactKeys returns a processor that replaces the values of the given keys
+func redactKeys(keys []string) entryProcessor {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	var redact entryProcessor
+	redact = func(fields []entryField) []entryField {
+		for i, f := range fields {
+			if _, ok := set[strings.ToLower(f.key)]; ok {
+				fields[i].value = redactedJSON
+			} else {
+				fields[i].value = processNested(f.value, redact)
+			}
+		}
+		return fields
+	}
+	return redact
+}
+
+// processNested applies the processor to the fields of a nested JSON object,
+// or to the elements of a nested JSON array. Other values are returned
+// unchanged.
+func processNested(value json.RawMessage, process entryProcessor) json.RawMessage {
+	switch {
+	case isObject(value):
+		return processObject(value, process)
+	case isArray(value):
+		return processArray(value, process)
+	}
+	return value
+}
+
+// processObject applies the processor to a nested JSON object
+func processObject(value json.RawMessage, process entryProcessor) json.RawMessage {
+	fields, err := parseEntry(value)
+	if err != nil {
+		return value
+	}
+	return encodeEntry(process(fields))
+}
+
+// processArray applies the processor to each element of a nested JSON array,
+// passed as a field without key
+func processArray(value json.RawMessage, process entryProcessor) json.RawMessage {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(value, &elements); err != nil {
+		return value
+	}
+	buf := make([]byte, 0, len(value))
+	buf = append(buf, '[')
+	for i, element := range elements {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if fields := process([]entryField{{value: element}}); len(fields) == 1 {
+			element = fields[0].value
+		}
+		buf = append(buf, element...)
+	}
+	return append(buf, ']')
+}
+
+// Scrubber replaces text matching Pattern in the message and string field
+// values. An empty Replacement uses RedactedValue.
+type Scrubber struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// Built-in scrubbers for common personal and sensitive data.
+var (
+	// ScrubEmails masks email addresses
+	ScrubEmails = Scrubber{
+		Pattern:     regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`),
+		Replacement: "[EMAIL]",
+	}
+	// ScrubCreditCards masks 13 to 19 digit card numbers, optionally separated by spaces or dashes
+	ScrubCreditCards = Scrubber{
+		Pattern:     regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`),
+		Replacement: "[CARD]",
+	}
+	// ScrubBearerTokens masks bearer tokens in authorization values
+	ScrubBearerTokens = Scrubber{
+		Pattern:     regexp.MustCompile(`(?i)bearer\s+[a-zA-Z0-9\-._~+/]+=*`),
+		Replacement: "Bearer [REDACTED]",
+	}
+)
+
+// DefaultScrubbers contains all the built-in scrubbers
+var DefaultScrubbers = []Scrubber{ScrubEmails, ScrubCreditCards, ScrubBearerTokens}
+
+// scrubValues returns a processor that applies the scrubbers to every string value
+func scrubValues(scrubbers []Scrubber) entryProcessor {
+	var scrub entryProcessor
+	scrub = func(fields []entryField) []entryField {
+		for i, f := range fields {
+			if s, ok := stringValue(f.value); ok {
+				if scrubbed := scrubString(s, scrubbers); scrubbed != s {
+					fields[i].value = mustMarshal(scrubbed)
+				}
+			} else {
+				fields[i].value = processNested(f.value, scrub)
+			}
+		}
+		return fields
+	}
+	return scrub
+}
+
+// scrubString applies the scrubbers to s
+func scrubString(s string, scrubbers []Scrubber) string {
+	for _, sc := range scrubbers {
+		replacement := sc.Replacement
+		if replacement == "" {
+			replacement = RedactedValue
+		}
+		s = sc.Pattern.ReplaceAllLiteralString(s, replacement)
+	}
+	return s
+}
+
+// pseudonymizeKeys returns a processor that replaces the values of the given
+// keys with their hex encoded HMAC-SHA256 under secret, so entries stay
+// correlatable without containing the raw identifiers
+func pseudonymizeKeys(keys []string, secret []byte) entryProcessor {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	var pseudonymize entryProcessor
+	pseudonymize = func(fields []entryField) []entryField {
+		for i, f := range fields {
+			if _, ok := set[strings.ToLower(f.key)]; ok {
+				fields[i].value = mustMarshal(keyedHash(f.value, secret))
+			} else {
+				fields[i].value = processNested(f.value, pseudonymize)
+			}
+		}
+		return fields
+	}
+	return pseudonymize
+}
+
+// keyedHash returns the hex encoded HMAC-SHA256 of a raw value. Strings are
+// hashed without their quotes so the hash matches hashing the plain value.
+func keyedHash(value json.RawMessage, secret []byte) string {
+	data := []byte(value)
+	if s, ok := stringValue(value); ok {
+		data = []byte(s)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
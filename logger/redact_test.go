@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+)
+
+// TestRedactKeys tests redacting sensitive fields by key
+func TestRedactKeys(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithRedactKeys(DefaultRedactKeys...),
+	)
+
+	log.WithFields(map[string]any{"Authorization": "Bearer abc"}).Info().
+		Str("user", "admin").
+		Str("password", "hunter2").
+		Dict("request", func(d *LogBuilder) {
+			d.Str("token", "xyz").Str("path", "/login")
+		}).
+		Msg("login")
+
+	logData := buf.String()
+	assertLogContains(t, logData, `"Authorization":"[REDACTED]"`, "info")
+	assertLogContains(t, logData, `"password":"[REDACTED]"`, "")
+	assertLogContains(t, logData, `"request":{"token":"[REDACTED]","path":"/login"}`, "")
+	assertLogContains(t, logData, `"user":"admin"`, "")
+	assertLogNotContains(t, logData, "hunter2")
+	assertLogNotContains(t, logData, "xyz")
+
+	// Objects inside arrays are redacted too
+	buf.Reset()
+	log.Info().
+		AddField("users", []map[string]any{{"name": "ana", "password": "hunter2"}, {"name": "bob"}}).
+		AddField("batches", [][]map[string]string{{{"token": "xyz"}}}).
+		Msg("import")
+	logData = buf.String()
+	assertLogContains(t, logData, `"users":[{"name":"ana","password":"[REDACTED]"},{"name":"bob"}]`, "info")
+	assertLogContains(t, logData, `"batches":[[{"token":"[REDACTED]"}]]`, "")
+	assertLogNotContains(t, logData, "hunter2")
+	assertLogNotContains(t, logData, "xyz")
+}
+
+// TestRedactMalformedJSON tests that malformed raw JSON cannot bypass redaction
+func TestRedactMalformedJSON(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithRedactKeys("password"))
+
+	log.Info().RawJSON("body", []byte("{bad")).Str("password", "hunter2").Msg("request")
+	assertLogContains(t, buf.String(), `"body":"{bad"`, "info")
+	assertLogContains(t, buf.String(), `"password":"[REDACTED]"`, "")
+	assertLogNotContains(t, buf.String(), "hunter2")
+
+	// Entries that are not valid JSON are replaced with an error entry
+	buf.Reset()
+	log.writer.Write([]byte(`{"password":"hunter2","body":{bad}` + "\n"))
+	assertLogContains(t, buf.String(), MalformedEntryMessage, "error")
+	assertLogNotContains(t, buf.String(), "hunter2")
+}
+
+// TestScrubbers tests regex scrubbing of messages and string fields
+func TestScrubbers(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithScrubbers(DefaultScrubbers...),
+		WithScrubbers(Scrubber{Pattern: regexp.MustCompile(`acct-\d+`)}),
+	)
+
+	log.Info().
+		Str("auth", "Bearer eyJhbGciOi.abc").
+		Str("card", "4111 1111 1111 1111").
+		Dict("owner", func(d *LogBuilder) { d.Str("account", "acct-1234") }).
+		Int("count", 1234567890123).
+		Msg("payment by john.doe@example.com")
+
+	logData := buf.String()
+	assertLogContains(t, logData, "payment by [EMAIL]", "info")
+	assertLogContains(t, logData, `"auth":"Bearer [REDACTED]"`, "")
+	assertLogContains(t, logData, `"card":"[CARD]"`, "")
+	assertLogContains(t, logData, `"owner":{"account":"[REDACTED]"}`, "")
+	assertLogContains(t, logData, `"count":1234567890123`, "")
+	assertLogNotContains(t, logData, "john.doe")
+
+	// Strings inside arrays are scrubbed too
+	buf.Reset()
+	log.Info().
+		AddField("cc", []string{"ana@example.com", "team"}).
+		AddField("owners", []map[string]string{{"account": "acct-99"}}).
+		Msg("shared")
+	logData = buf.String()
+	assertLogContains(t, logData, `"cc":["[EMAIL]","team"]`, "info")
+	assertLogContains(t, logData, `"owners":[{"account":"[REDACTED]"}]`, "")
+	assertLogNotContains(t, logData, "ana@example.com")
+}
+
+// TestPseudonymization tests replacing identifiers with keyed hashes
+func TestPseudonymization(t *testing.T) {
+	var buf bytes.Buffer
+	secret := []byte("test-secret")
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithPseudonymization(secret, "user_id", "email"),
+	)
+
+	log.Info().Str("user_id", "u-42").Str("email", "a@b.c").Str("action", "login").Msg("first")
+	first := buf.String()
+	buf.Reset()
+	log.Info().Str("user_id", "u-42").Msg("second")
+	second := buf.String()
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("u-42"))
+	expected := `"user_id":"` + hex.EncodeToString(mac.Sum(nil)) + `"`
+
+	assertLogContains(t, first, expected, "info")
+	assertLogContains(t, second, expected, "info")
+	assertLogContains(t, first, `"action":"login"`, "")
+	assertLogNotContains(t, first, "u-42")
+	assertLogNotContains(t, first, "a@b.c")
+
+	// Objects inside arrays are pseudonymized too
+	buf.Reset()
+	log.Info().AddField("recipients", []map[string]string{{"email": "a@b.c", "role": "to"}}).Msg("sent")
+	mac.Reset()
+	mac.Write([]byte("a@b.c"))
+	assertLogContains(t, buf.String(), `"recipients":[{"email":"`+hex.EncodeToString(mac.Sum(nil))+`","role":"to"}]`, "info")
+	assertLogNotContains(t, buf.String(), "a@b.c")
+}
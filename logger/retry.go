@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Default values used by Retry for zero RetryConfig values
+const (
+	DefaultRetryMaxAttempts    = 5
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// spoolExt is the extension of the files written to RetryConfig.SpoolDir
+const spoolExt = ".spool"
+
+// RetryConfig sets how Retry retries a failing sink
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles on every
+	// retry, up to MaxBackoff
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait by up to this fraction, between 0 and 1, so
+	// that clients do not retry in lockstep
+	Jitter float64
+	// SpoolDir, if set, is where batches are written when all attempts fail,
+	// to be sent later with ReplaySpool
+	SpoolDir string
+}
+
+// spoolSeq orders the spool files written within the same nanosecond
+var spoolSeq atomic.Uint64
+
+// Retry wraps a sink so that failed batches are retried with exponential
+// backoff. It is meant for remote sinks used with a Batcher:
+//
+//	send := logger.Retry(sendToSink, logger.RetryConfig{MaxAttempts: 3, Jitter: 0.2})
+//	b := logger.NewBatcher(send, logger.BatchConfig{})
+func Retry(flush BatchFunc, cfg RetryConfig) BatchFunc {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	return func(batch [][]byte) error {
+		backoff := cfg.InitialBackoff
+		var err error
+		for attempt := 1; ; attempt++ {
+			if err = flush(batch); err == nil {
+				return nil
+			}
+			if attempt == cfg.MaxAttempts {
+				break
+			}
+			time.Sleep(jitter(backoff, cfg.Jitter))
+			backoff = min(2*backoff, cfg.MaxBackoff)
+		}
+		err = fmt.Errorf("logger: sink failed after %d attempts: %w", cfg.MaxAttempts, err)
+		if cfg.SpoolDir != "" {
+			if serr := spool(cfg.SpoolDir, batch); serr != nil {
+				return fmt.Errorf("%w; spooling failed: %v", err, serr)
+			}
+		}
+		return err
+	}
+}
+
+// jitter reduces d by a random fraction of up to factor
+func jitter(d time.Duration, factor float64) time.Duration {
+	if factor <= 0 {
+		return d
+	}
+	return d - time.Duration(rand.Float64()*min(factor, 1)*float64(d))
+}
+
+// spool writes the batch to a new file in dir
+func spool(dir string, batch [][]byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	name := strconv.FormatInt(time.Now().UnixNano(), 10) + "-" + strconv.FormatUint(spoolSeq.Add(1), 10) + spoolExt
+	tmp := filepath.Join(dir, "."+name)
+	if err := os.WriteFile(tmp, bytes.Join(batch, nil), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, name))
+}
+
+// ReplaySpool sends the batches spooled in dir by Retry, oldest first,
+// removing each file once it is sent. It stops at the first error.
+func ReplaySpool(dir string, flush BatchFunc) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+spoolExt))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var batch [][]byte
+		for _, entry := range bytes.SplitAfter(data, []byte("\n")) {
+			if len(entry) > 0 {
+				batch = append(batch, entry)
+			}
+		}
+		if len(batch) > 0 {
+			if err := flush(batch); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
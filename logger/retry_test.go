@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRetry tests retrying a failing sink with backoff
+func TestRetry(t *testing.T) {
+	var attempts int
+	var times []time.Time
+	flaky := func(batch [][]byte) error {
+		attempts++
+		times = append(times, time.Now())
+		if attempts < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	}
+
+	send := Retry(flaky, RetryConfig{MaxAttempts: 3, InitialBackoff: 5 * time.Millisecond})
+	if err := send([][]byte{[]byte("entry\n")}); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", attempts)
+	}
+	// The backoff doubles between attempts
+	if first, second := times[1].Sub(times[0]), times[2].Sub(times[1]); first < 5*time.Millisecond || second < 10*time.Millisecond {
+		t.Errorf("Expected exponential backoff, got %v then %v", first, second)
+	}
+}
+
+// TestRetrySpool tests spooling batches that fail every attempt and replaying them
+func TestRetrySpool(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "spool")
+	down := errors.New("unavailable")
+	send := Retry(func([][]byte) error { return down }, RetryConfig{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		Jitter:         0.5,
+		SpoolDir:       dir,
+	})
+
+	if err := send([][]byte{[]byte("a\n"), []byte("b\n")}); !errors.Is(err, down) {
+		t.Fatalf("Expected the sink error, got %v", err)
+	}
+	if err := send([][]byte{[]byte("c\n")}); !errors.Is(err, down) {
+		t.Fatalf("Expected the sink error, got %v", err)
+	}
+
+	rec := &batchRecorder{}
+	if err := ReplaySpool(dir, rec.flush); err != nil {
+		t.Fatalf("ReplaySpool returned an error: %v", err)
+	}
+	if len(rec.batches) != 2 || len(rec.batches[0]) != 2 || rec.batches[0][1] != "b\n" || rec.batches[1][0] != "c\n" {
+		t.Errorf("Expected the spooled batches in order, got %q", rec.batches)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected replayed files to be removed, got %d", len(files))
+	}
+}
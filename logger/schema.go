@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FieldType is the JSON type expected for a field by a Schema
+type FieldType string
+
+// Field types supported by Schema.
+const (
+	TypeString FieldType = "string"
+	TypeNumber FieldType = "number"
+	TypeBool   FieldType = "boolean"
+	TypeObject FieldType = "object"
+	TypeArray  FieldType = "array"
+)
+
+// SchemaMode sets what happens to entries that do not conform to the schema
+type SchemaMode int
+
+const (
+	// SchemaAnnotate writes non-conforming entries with the violations listed in "_schema_errors"
+	SchemaAnnotate SchemaMode = iota
+	// SchemaReject drops non-conforming entries
+	SchemaReject
+)
+
+// SchemaErrorsFieldName is the key listing the schema violations of an annotated entry
+const SchemaErrorsFieldName = "_schema_errors"
+
+// Schema describes the fields every entry must contain
+type Schema struct {
+	// Required lists the keys that must be present
+	Required []string
+	// Types sets the expected JSON type of fields, checked when they are present
+	Types map[string]FieldType
+	// Mode sets what happens to non-conforming entries
+	Mode SchemaMode
+}
+
+// validate returns the violations of the schema in an entry
+func (s *Schema) validate(fields []entryField) []string {
+	present := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		present[f.key] = f.value
+	}
+
+	var violations []string
+	for _, key := range s.Required {
+		if _, ok := present[key]; !ok {
+			violations = append(violations, fmt.Sprintf("missing required field %q", key))
+		}
+	}
+	for _, f := range fields {
+		expected, ok := s.Types[f.key]
+		if !ok {
+			continue
+		}
+		if actual := jsonType(f.value); actual != expected {
+			violations = append(violations, fmt.Sprintf("field %q is %s, expected %s", f.key, actual, expected))
+		}
+	}
+	return violations
+}
+
+// validateSchema returns a processor that annotates or rejects entries not conforming to s
+func validateSchema(s *Schema) entryProcessor {
+	return func(fields []entryField) []entryField {
+		violations := s.validate(fields)
+		if len(violations) == 0 {
+			return fields
+		}
+		if s.Mode == SchemaReject {
+			return nil
+		}
+		return append(fields, entryField{key: SchemaErrorsFieldName, value: mustMarshal(violations)})
+	}
+}
+
+// jsonType returns the JSON type of a raw value
+func jsonType(value json.RawMessage) FieldType {
+	if len(value) == 0 {
+		return "null"
+	}
+	switch value[0] {
+	case '"':
+		return TypeString
+	case '{':
+		return TypeObject
+	case '[':
+		return TypeArray
+	case 't', 'f':
+		return TypeBool
+	case 'n':
+		return "null"
+	}
+	return TypeNumber
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSchemaValidation tests annotating and rejecting non-conforming entries
+func TestSchemaValidation(t *testing.T) {
+	var buf bytes.Buffer
+
+	schema := &Schema{
+		Required: []string{"request_id"},
+		Types:    map[string]FieldType{"status": TypeNumber},
+	}
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithSchema(schema))
+
+	log.Info().Str("request_id", "req-1").Int("status", 200).Msg("valid entry")
+	assertLogContains(t, buf.String(), "valid entry", "info")
+	assertLogNotContains(t, buf.String(), SchemaErrorsFieldName)
+
+	buf.Reset()
+	log.Info().Str("status", "ok").Msg("invalid entry")
+	logData := buf.String()
+	assertLogContains(t, logData, "invalid entry", "info")
+	assertLogContains(t, logData, `missing required field \"request_id\"`, "")
+	assertLogContains(t, logData, `field \"status\" is string, expected number`, "")
+
+	schema.Mode = SchemaReject
+	log = NewWithOptions(WithOutput(&buf), WithCaller(false), WithSchema(schema))
+
+	buf.Reset()
+	log.Info().Msg("rejected entry")
+	if buf.Len() > 0 {
+		t.Errorf("Non-conforming entry should be rejected, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"regexp"
+
+	"github.com/rs/zerolog"
+)
+
+// SecretMask replaces the secrets found by the secret detector
+const SecretMask = "[SECRET]"
+
+// secretPattern is a kind of secret recognized by the secret detector
+type secretPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// secretPatterns are the secrets recognized when Config.DetectSecrets is enabled
+var secretPatterns = []secretPattern{
+	{"aws_access_key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)},
+	{"private_key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?s:.*?)(?:-----END [A-Z ]*PRIVATE KEY-----|$)`)},
+}
+
+// detectSecrets returns a processor that masks likely secrets in string values
+// and writes a warning to meta for every entry where one was found. The
+// warning includes the entry's caller, if any, to identify the call site.
+func detectSecrets(meta zerolog.Logger, callerKey string) entryProcessor {
+	return func(fields []entryField) []entryField {
+		found := map[string]struct{}{}
+		fields = maskSecrets(fields, found)
+		if len(found) == 0 {
+			return fields
+		}
+
+		kinds := make([]string, 0, len(found))
+		for _, p := range secretPatterns {
+			if _, ok := found[p.name]; ok {
+				kinds = append(kinds, p.name)
+			}
+		}
+		warning := meta.Warn().Strs("secret_types", kinds)
+		for _, f := range fields {
+			if f.key == callerKey {
+				warning.RawJSON("source", f.value)
+			}
+		}
+		warning.Msg("possible secret detected in log entry")
+		return fields
+	}
+}
+
+// maskSecrets replaces the secrets in string values, recording the kinds found
+func maskSecrets(fields []entryField, found map[string]struct{}) []entryField {
+	for i, f := range fields {
+		if s, ok := stringValue(f.value); ok {
+			masked := s
+			for _, p := range secretPatterns {
+				if p.pattern.MatchString(masked) {
+					found[p.name] = struct{}{}
+					masked = p.pattern.ReplaceAllLiteralString(masked, SecretMask)
+				}
+			}
+			if masked != s {
+				fields[i].value = mustMarshal(masked)
+			}
+		} else {
+			fields[i].value = processNested(f.value, func(nested []entryField) []entryField {
+				return maskSecrets(nested, found)
+			})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,89 @@
+package logger
+
+// Security event field names.
+const (
+	SecurityEventTypeFieldName = "event_type"
+	SecurityEventFieldName     = "event"
+	SecurityUserFieldName      = "user"
+	SecurityIPFieldName        = "ip"
+	SecurityResourceFieldName  = "resource"
+)
+
+// SecurityEventType is the value of the event_type field of security events
+const SecurityEventType = "security"
+
+// Security event names, following the OWASP logging vocabulary.
+const (
+	EventLoginSuccess   = "authn_login_success"
+	EventLoginFailure   = "authn_login_fail"
+	EventLoginLockout   = "authn_login_lock"
+	EventPasswordChange = "authn_password_change"
+	EventTokenRevoked   = "authn_token_revoked"
+	EventAuthzFailure   = "authz_fail"
+	EventPrivilegeGrant = "privilege_permissions_changed"
+)
+
+// SecurityLogger writes authentication and authorization events with stable
+// field names that SIEM rules can rely on
+type SecurityLogger struct {
+	logger *Logger
+}
+
+// Security returns a logger for security events
+func (l *Logger) Security() *SecurityLogger {
+	return &SecurityLogger{logger: l}
+}
+
+// LoginSuccess logs a successful login at info level
+func (s *SecurityLogger) LoginSuccess(user, ip string) {
+	s.event(s.logger.Info(), EventLoginSuccess, user).
+		Str(SecurityIPFieldName, ip).
+		Msgf("user %s logged in successfully", user)
+}
+
+// LoginFailure logs a failed login attempt at warn level
+func (s *SecurityLogger) LoginFailure(user, ip string) {
+	s.event(s.logger.Warn(), EventLoginFailure, user).
+		Str(SecurityIPFieldName, ip).
+		Msgf("user %s login failed", user)
+}
+
+// LoginLockout logs an account locked after too many failed logins at error level
+func (s *SecurityLogger) LoginLockout(user, ip string) {
+	s.event(s.logger.Error(), EventLoginLockout, user).
+		Str(SecurityIPFieldName, ip).
+		Msgf("user %s login locked because maximum retries exceeded", user)
+}
+
+// PasswordChange logs a password change at info level
+func (s *SecurityLogger) PasswordChange(user string) {
+	s.event(s.logger.Info(), EventPasswordChange, user).
+		Msgf("user %s has successfully changed their password", user)
+}
+
+// TokenRevoked logs a revoked token or session at info level
+func (s *SecurityLogger) TokenRevoked(user string) {
+	s.event(s.logger.Info(), EventTokenRevoked, user).
+		Msgf("a token has been revoked for user %s", user)
+}
+
+// AuthorizationFailure logs an attempt to access a resource without permission at error level
+func (s *SecurityLogger) AuthorizationFailure(user, resource string) {
+	s.event(s.logger.Error(), EventAuthzFailure, user).
+		Str(SecurityResourceFieldName, resource).
+		Msgf("user %s attempted to access %s without authorization", user, resource)
+}
+
+// PrivilegeChange logs a change of a user's permissions at warn level
+func (s *SecurityLogger) PrivilegeChange(user, resource string) {
+	s.event(s.logger.Warn(), EventPrivilegeGrant, user).
+		Str(SecurityResourceFieldName, resource).
+		Msgf("permissions of user %s changed on %s", user, resource)
+}
+
+// event adds the common security event fields to the log builder
+func (s *SecurityLogger) event(lb *LogBuilder, name, user string) *LogBuilder {
+	return lb.Str(SecurityEventTypeFieldName, SecurityEventType).
+		Str(SecurityEventFieldName, name+":"+user).
+		Str(SecurityUserFieldName, user)
+}
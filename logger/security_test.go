@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSecurityEvents tests the security event helpers
+func TestSecurityEvents(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, WithCaller: false})
+
+	log.Security().LoginFailure("alice", "10.0.0.1")
+	logData := buf.String()
+	assertLogContains(t, logData, `"event_type":"security","event":"authn_login_fail:alice","user":"alice","ip":"10.0.0.1"`, "warn")
+	assertLogContains(t, logData, "user alice login failed", "")
+
+	buf.Reset()
+	log.Security().AuthorizationFailure("bob", "/admin")
+	assertLogContains(t, buf.String(), `"event":"authz_fail:bob","user":"bob","resource":"/admin"`, "error")
+
+	buf.Reset()
+	log.Security().LoginSuccess("alice", "10.0.0.1")
+	assertLogContains(t, buf.String(), `"event":"authn_login_success:alice"`, "info")
+}
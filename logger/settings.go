@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Names of the settings read by NewFromViper, NewFromFlags and NewFromCLIContext.
+const (
+	SettingLevel       = "level"
+	SettingFormat      = "format"
+	SettingFile        = "file"
+	SettingCaller      = "caller"
+	SettingTimeFormat  = "time_format"
+	SettingServiceName = "service_name"
+)
+
+// configFromSettings builds a configuration on top of DefaultConfig from the
+// named settings found by get. The format is parsed with ParseFormat, and the file, if set, is opened for appending.
+func configFromSettings(get func(name string) (string, bool)) (Config, error) {
+	cfg := DefaultConfig()
+	if s, ok := get(SettingLevel); ok {
+		level, err := ParseLevel(s)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Level = level
+	}
+	if s, ok := get(SettingFormat); ok {
+		format, err := ParseFormat(s)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Format = format
+		cfg.Pretty = format == FormatPretty
+	}
+	if s, ok := get(SettingCaller); ok {
+		caller, err := strconv.ParseBool(s)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid log caller setting: %s", s)
+		}
+		cfg.WithCaller = caller
+	}
+	if s, ok := get(SettingTimeFormat); ok {
+		cfg.TimeFormat = s
+	}
+	if s, ok := get(SettingServiceName); ok {
+		cfg.ServiceName = s
+	}
+	if s, ok := get(SettingFile); ok && s != "" {
+		file, err := OpenFile(s)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Output = file
+	}
+	return cfg, nil
+}
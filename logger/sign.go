@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+)
+
+// SignatureFieldName is the key of the entry signature added with Config.SigningKey
+const SignatureFieldName = "_sig"
+
+// ErrTampered is returned by Verify when an entry was modified, removed or reordered
+var ErrTampered = errors.New("logger: log integrity check failed")
+
+// signatureMarker precedes the signature at the end of a signed entry
+var signatureMarker = []byte(`"` + SignatureFieldName + `":"`)
+
+// signWriter appends to each entry an HMAC covering the entry and the
+// signature of the previous one, so that any change breaks the chain
+type signWriter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	mac  hash.Hash
+	prev []byte
+}
+
+// newSignWriter wraps out with a signWriter keyed with key
+func newSignWriter(out io.Writer, key []byte) io.Writer {
+	return &signWriter{out: out, mac: hmac.New(sha256.New, key)}
+}
+
+// Write implements io.Writer. Entries that are not JSON objects are written unsigned.
+func (w *signWriter) Write(p []byte) (int, error) {
+	entry := bytes.TrimRight(p, "\n")
+	if len(entry) < 2 || entry[0] != '{' || entry[len(entry)-1] != '}' {
+		return w.out.Write(p)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sig := chainSignature(w.mac, w.prev, entry)
+	line := make([]byte, 0, len(entry)+len(signatureMarker)+len(sig)+4)
+	line = append(line, entry[:len(entry)-1]...)
+	if len(entry) > 2 {
+		line = append(line, ',')
+	}
+	line = append(line, signatureMarker...)
+	line = append(line, sig...)
+	line = append(line, "\"}\n"...)
+	if _, err := w.out.Write(line); err != nil {
+		return 0, err
+	}
+	w.prev = sig
+	return len(p), nil
+}
+
+// chainSignature returns the hex HMAC of the previous signature and the entry
+func chainSignature(mac hash.Hash, prev, entry []byte) []byte {
+	mac.Reset()
+	mac.Write(prev)
+	mac.Write(entry)
+	return hex.AppendEncode(nil, mac.Sum(nil))
+}
+
+// Verify checks the signature chain of a log written with Config.SigningKey,
+// from its first entry. It returns an error wrapping ErrTampered with the
+// line number of the first entry that does not verify.
+func Verify(r io.Reader, key []byte) error {
+	mac := hmac.New(sha256.New, key)
+	var prev []byte
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		entry, sig, ok := splitSignature(line)
+		if !ok || !hmac.Equal(sig, chainSignature(mac, prev, entry)) {
+			return fmt.Errorf("%w at line %d", ErrTampered, n)
+		}
+		prev = append(prev[:0], sig...)
+	}
+	return scanner.Err()
+}
+
+// splitSignature separates a signed line into the original entry and its signature
+func splitSignature(line []byte) (entry, sig []byte, ok bool) {
+	i := bytes.LastIndex(line, signatureMarker)
+	if i < 1 || !bytes.HasSuffix(line, []byte(`"}`)) {
+		return nil, nil, false
+	}
+	sig = line[i+len(signatureMarker) : len(line)-2]
+	head := line[:i]
+	if head[len(head)-1] == ',' {
+		head = head[:len(head)-1]
+	}
+	entry = append(append(make([]byte, 0, len(head)+1), head...), '}')
+	return entry, sig, true
+}
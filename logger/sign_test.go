@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestSigning tests signing entries and verifying the chain
+func TestSigning(t *testing.T) {
+	key := []byte("audit-key")
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false), WithSigning(key))
+
+	log.Info().Str("user", "alice").Msg("login")
+	log.Warn().Str("user", "bob").Msg("password change")
+	log.InfoMsg("logout")
+
+	var logData map[string]any
+	if err := json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if sig, _ := logData[SignatureFieldName].(string); len(sig) != 64 {
+		t.Errorf("Expected a hex signature, got: %s", buf.String())
+	}
+
+	signed := buf.String()
+	if err := Verify(strings.NewReader(signed), key); err != nil {
+		t.Fatalf("Expected the log to verify, got %v", err)
+	}
+
+	lines := strings.SplitAfter(signed, "\n")
+	tests := []struct {
+		name string
+		log  string
+		key  []byte
+		line string
+	}{
+		{"modified", strings.Replace(signed, "bob", "eve", 1), key, "line 2"},
+		{"removed", lines[0] + lines[2], key, "line 2"},
+		{"reordered", lines[1] + lines[0] + lines[2], key, "line 1"},
+		{"wrong key", signed, []byte("other"), "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(strings.NewReader(tt.log), tt.key)
+			if !errors.Is(err, ErrTampered) || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected tampering detected at %s, got %v", tt.line, err)
+			}
+		})
+	}
+}
+
+// TestSplitSignature tests signing an entry without fields
+func TestSplitSignature(t *testing.T) {
+	var buf bytes.Buffer
+	w := newSignWriter(&buf, []byte("key"))
+	w.Write([]byte("{}\n"))
+	if !strings.HasPrefix(buf.String(), `{"_sig":"`) {
+		t.Fatalf("Unexpected signed entry: %s", buf.String())
+	}
+	if err := Verify(&buf, []byte("key")); err != nil {
+		t.Errorf("Expected the entry to verify, got %v", err)
+	}
+}
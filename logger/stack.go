@@ -0,0 +1,256 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// GoroutineFieldName is the key used for the goroutine ID
+const GoroutineFieldName = "goroutine"
+
+// maxStackDepth limits the number of frames captured for a stack trace
+const maxStackDepth = 32
+
+// packageDir is the directory of this package, used to skip its own frames
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// internalDirs are the directories whose frames are skipped when looking for
+// the caller. Packages wrapping the logger skip their own frames with
+// CallerSkipFrame instead.
+var internalDirs = []string{packageDir}
+
+// Stack adds a stack trace to the log. If an error with a stack was added and
+// zerolog.ErrorStackMarshaler is set (e.g. pkgerrors.MarshalStack), the error's
+// stack is used; otherwise the stack of the current goroutine is captured.
+func (lb *LogBuilder) Stack() *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.stack = true
+	return lb
+}
+
+// Caller adds the caller information to the log, even if the logger was
+// created without WithCaller, to locate important entries without paying for
+// the caller lookup on every entry
+func (lb *LogBuilder) Caller() *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.caller = true
+	return lb
+}
+
+// CallerSkipFrame skips the given number of frames outside the logger when
+// looking for the caller, so packages wrapping the logger report the caller
+// of their own functions
+func (lb *LogBuilder) CallerSkipFrame(skip int) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	lb.callerSkip = skip
+	return lb
+}
+
+// addStack writes the stack trace field to the event
+func (lb *LogBuilder) addStack() {
+	if lb.event == nil {
+		return
+	}
+	if lb.err != nil && zerolog.ErrorStackMarshaler != nil {
+		if st := zerolog.ErrorStackMarshaler(lb.err); st != nil {
+			lb.event.Interface(zerolog.ErrorStackFieldName, st)
+			return
+		}
+	}
+	lb.event.Interface(zerolog.ErrorStackFieldName, captureStack())
+}
+
+// addCaller writes the caller field with the first frame outside the logger.
+// The frame is looked up instead of skipping a fixed number of frames because
+// the depth differs between Msg, Msgf and the Logger shortcuts.
+func (lb *LogBuilder) addCaller() {
+	if lb.event == nil {
+		return
+	}
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	skip := lb.callerSkip
+	for {
+		frame, more := frames.Next()
+		if internal := isInternalFrame(frame); !internal && skip > 0 {
+			skip--
+		} else if !internal {
+			file := frame.File
+			if lb.logger.relativeCaller {
+				file = relativePath(file)
+			} else {
+				file = trimCallerPath(file, lb.logger.callerTrim)
+			}
+			lb.event.Str(zerolog.CallerFieldName, zerolog.CallerMarshalFunc(frame.PC, file, frame.Line))
+			if lb.logger.callerFunc {
+				lb.event.Str(CallerFuncFieldName, packageFuncName(frame.Function))
+			}
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// captureStack returns the current stack in the same shape as pkgerrors.MarshalStack,
+// skipping the runtime and this package's own frames.
+func captureStack() []map[string]string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	out := make([]map[string]string, 0, n)
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame) {
+			out = append(out, map[string]string{
+				"source": filepath.Base(frame.File),
+				"line":   strconv.Itoa(frame.Line),
+				"func":   shortFuncName(frame.Function),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// isInternalFrame reports whether the frame belongs to the logger or a package wrapping it
+func isInternalFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") {
+		return true
+	}
+	return slices.Contains(internalDirs, filepath.Dir(frame.File)) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// callerTrimPrefix returns the prefix trimmed from caller paths: prefix if set,
+// otherwise the main module path
+func callerTrimPrefix(prefix string) string {
+	if prefix != "" {
+		return strings.TrimSuffix(prefix, "/")
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}
+
+// trimCallerPath removes prefix and everything before it from file, if file
+// contains prefix as whole path elements. Without -trimpath, file is absolute
+// and does not contain the module path, so the root directory of the module
+// named prefix is removed instead.
+func trimCallerPath(file, prefix string) string {
+	if prefix == "" {
+		return file
+	}
+	i := strings.Index(file, prefix+"/")
+	if i < 0 || (i > 0 && file[i-1] != '/') {
+		if root := moduleRoot(filepath.Dir(file), prefix); root != "" {
+			if rel, err := filepath.Rel(root, file); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+		return file
+	}
+	return file[i+len(prefix)+1:]
+}
+
+// moduleRoots caches the results of moduleRoot by directory and module path
+var moduleRoots sync.Map
+
+// moduleRoot returns the directory of the go.mod closest to dir if it declares
+// the module path, or "" otherwise, such as when the sources are not on disk
+func moduleRoot(dir, module string) string {
+	key := [2]string{dir, module}
+	if root, ok := moduleRoots.Load(key); ok {
+		return root.(string)
+	}
+	root := ""
+	for d := dir; ; {
+		if data, err := os.ReadFile(filepath.Join(d, "go.mod")); err == nil {
+			if modulePath(data) == module {
+				root = d
+			}
+			break
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			break
+		}
+		d = parent
+	}
+	moduleRoots.Store(key, root)
+	return root
+}
+
+// modulePath returns the path declared by the module directive of a go.mod
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// packageFuncName strips the package path from a function name, keeping the
+// package name, such as "api.(*Handler).Serve"
+func packageFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// shortFuncName strips the package path from a function name
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// goroutineHook adds the ID of the logging goroutine to each entry
+type goroutineHook struct{}
+
+// Run implements zerolog.Hook
+func (goroutineHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	e.Uint64(GoroutineFieldName, goroutineID())
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from the
+// header of its stack trace ("goroutine 42 [running]:")
+func goroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	field := bytes.Fields(bytes.TrimPrefix(buf[:n], []byte("goroutine ")))
+	if len(field) == 0 {
+		return 0
+	}
+	id, _ := strconv.ParseUint(string(field[0]), 10, 64)
+	return id
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestStackTrace tests automatic and per-entry stack traces
+func TestStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(
+		WithOutput(&buf),
+		WithCaller(false),
+		WithStackTrace(true),
+	)
+
+	log.ErrorMsg("something failed")
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	stack, ok := logData["stack"].([]any)
+	if !ok || len(stack) == 0 {
+		t.Fatalf("Expected non-empty 'stack' array, got: %s", buf.String())
+	}
+	frame := stack[0].(map[string]any)
+	if frame["func"] != "TestStackTrace" || frame["source"] != "stack_test.go" {
+		t.Errorf("Expected first frame to be the caller, got %v", frame)
+	}
+
+	// Info entries do not get a stack unless requested
+	buf.Reset()
+	log.InfoMsg("no stack")
+	assertLogNotContains(t, buf.String(), `"stack"`)
+
+	buf.Reset()
+	log.Info().Stack().Msg("with stack")
+	assertLogContains(t, buf.String(), `"stack"`, "info")
+}
+
+// TestStackTraceErrorMarshaler tests using zerolog.ErrorStackMarshaler for error stacks
+func TestStackTraceErrorMarshaler(t *testing.T) {
+	var buf bytes.Buffer
+
+	orig := zerolog.ErrorStackMarshaler
+	defer func() { zerolog.ErrorStackMarshaler = orig }()
+	zerolog.ErrorStackMarshaler = func(err error) any {
+		return "error stack"
+	}
+
+	log := New(Config{Output: &buf})
+	log.Error().WithError(errors.New("failure")).Stack().Msg("failed")
+
+	assertLogContains(t, buf.String(), `"stack":"error stack"`, "error")
+}
+
+// TestGoroutineID tests the optional goroutine ID field
+func TestGoroutineID(t *testing.T) {
+	var buf bytes.Buffer
+
+	log := NewWithOptions(WithOutput(&buf), WithCaller(false))
+	log.InfoMsg("no goroutine")
+	assertLogNotContains(t, buf.String(), `"goroutine"`)
+
+	buf.Reset()
+	log = NewWithOptions(WithOutput(&buf), WithCaller(false), WithGoroutineID(true))
+	log.InfoMsg("main goroutine")
+	done := make(chan struct{})
+	go func() {
+		log.Info().Str("key", "value").Msg("other goroutine")
+		close(done)
+	}()
+	<-done
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got: %s", buf.String())
+	}
+	ids := make([]float64, 0, 2)
+	for _, line := range lines {
+		var logData map[string]any
+		if err := json.Unmarshal(line, &logData); err != nil {
+			t.Fatalf("Could not parse log as JSON: %v", err)
+		}
+		id, ok := logData[GoroutineFieldName].(float64)
+		if !ok || id == 0 {
+			t.Fatalf("Expected goroutine ID, got: %s", line)
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("Expected different goroutine IDs, got %v", ids)
+	}
+}
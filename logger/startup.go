@@ -0,0 +1,104 @@
+package logger
+
+import (
+	"os"
+	"runtime"
+	"sort"
+)
+
+// Startup summary field names.
+const (
+	StartupVersionFieldName   = "version"
+	StartupListenFieldName    = "listen"
+	StartupConfigFieldName    = "config"
+	StartupGoVersionFieldName = "go_version"
+	StartupPIDFieldName       = "pid"
+)
+
+// StartupMessage is the message of the startup summary entry
+const StartupMessage = "service started"
+
+// StartupEvent collects the facts about a starting service and writes them as
+// a single info entry, instead of a scattering of init logs:
+//
+//	log.Startup().
+//		Version(version).
+//		Listen(":8080").
+//		Config("db_pool", cfg.PoolSize).
+//		Fact("region", region).
+//		Send()
+//
+// The Go version and the process ID are always added. Config values go
+// through redaction like any other field.
+type StartupEvent struct {
+	logger  *Logger
+	version string
+	listen  []string
+	config  map[string]any
+	facts   []contextField
+}
+
+// Startup starts a new startup summary entry
+func (l *Logger) Startup() *StartupEvent {
+	return &StartupEvent{logger: l}
+}
+
+// Version sets the version of the service
+func (s *StartupEvent) Version(version string) *StartupEvent {
+	s.version = version
+	return s
+}
+
+// Listen adds an address the service listens on
+func (s *StartupEvent) Listen(addr string) *StartupEvent {
+	s.listen = append(s.listen, addr)
+	return s
+}
+
+// Config adds a configuration value, grouped under the config field
+func (s *StartupEvent) Config(key string, value any) *StartupEvent {
+	if s.config == nil {
+		s.config = make(map[string]any)
+	}
+	s.config[key] = value
+	return s
+}
+
+// Fact adds a top-level field, such as the region or the build commit
+func (s *StartupEvent) Fact(key string, value any) *StartupEvent {
+	s.facts = append(s.facts, contextField{key: key, value: value})
+	return s
+}
+
+// Send writes the startup summary entry
+func (s *StartupEvent) Send() {
+	lb := s.logger.Info()
+	if lb == nil {
+		return
+	}
+
+	if s.version != "" {
+		lb.Str(StartupVersionFieldName, s.version)
+	}
+	if len(s.listen) > 0 {
+		lb.event.Strs(lb.key(StartupListenFieldName), s.listen)
+	}
+	if len(s.config) > 0 {
+		keys := make([]string, 0, len(s.config))
+		for k := range s.config {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		lb.Dict(StartupConfigFieldName, func(d *LogBuilder) {
+			for _, k := range keys {
+				d.AddField(k, s.config[k])
+			}
+		})
+	}
+	for _, f := range s.facts {
+		lb.AddField(f.key, f.value)
+	}
+	lb.Str(StartupGoVersionFieldName, runtime.Version()).
+		Int(StartupPIDFieldName, os.Getpid()).
+		Msg(StartupMessage)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+// TestStartup tests the startup summary entry
+func TestStartup(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithRedactKeys("password"))
+
+	log.Startup().
+		Version("1.4.2").
+		Listen(":8080").
+		Listen(":9090").
+		Config("pool_size", 10).
+		Config("password", "hunter2").
+		Fact("region", "eu-west-1").
+		Send()
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if entry["message"] != StartupMessage || entry["level"] != "info" {
+		t.Errorf("Unexpected entry: %s", buf.String())
+	}
+	if entry["version"] != "1.4.2" || entry["region"] != "eu-west-1" {
+		t.Errorf("Unexpected facts: %s", buf.String())
+	}
+	if want := []any{":8080", ":9090"}; !reflect.DeepEqual(entry["listen"], want) {
+		t.Errorf("Expected listen %v, got %v", want, entry["listen"])
+	}
+	want := map[string]any{"pool_size": 10.0, "password": RedactedValue}
+	if !reflect.DeepEqual(entry["config"], want) {
+		t.Errorf("Expected config %v, got %v", want, entry["config"])
+	}
+	if entry["go_version"] != runtime.Version() || entry["pid"] != float64(os.Getpid()) {
+		t.Errorf("Unexpected runtime facts: %s", buf.String())
+	}
+
+	// Optional facts are omitted when not set
+	buf.Reset()
+	log.Startup().Send()
+	assertLogContains(t, buf.String(), StartupMessage, "info")
+	assertLogNotContains(t, buf.String(), `"version"`)
+	assertLogNotContains(t, buf.String(), `"config"`)
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"io"
+	"sync"
+)
+
+// TenantFieldName is the key of the tenant ID added by ForTenant
+const TenantFieldName = "tenant"
+
+// tenantWriters caches the writer chain built for each tenant output, shared
+// by a logger and its children so every tenant logger writes through the same
+// chain
+type tenantWriters struct {
+	mu      sync.Mutex
+	writers map[string]tenantWriter
+}
+
+// tenantWriter is the output of a tenant and the writer chain built on it
+type tenantWriter struct {
+	output io.Writer
+	writer io.Writer
+}
+
+// get returns the output of the tenant and its writer chain, or nil if the
+// tenant writes to the shared output
+func (t *tenantWriters) get(l *Logger, tenant string) (output, writer io.Writer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if w, ok := t.writers[tenant]; ok {
+		return w.output, w.writer
+	}
+	output = l.cfg.TenantOutput(tenant)
+	if output != nil {
+		writer = newWriter(l.cfg, output, l.serviceName)
+	}
+	t.writers[tenant] = tenantWriter{output: output, writer: writer}
+	return output, writer
+}
+
+// ForTenant returns a child logger adding the tenant ID in the "tenant" field.
+// If Config.TenantOutput returns an output for the tenant, the child writes to
+// it instead of the shared output, so each tenant can get its own file or
+// stream:
+//
+//	log := logger.NewWithOptions(logger.WithTenantOutput(func(tenant string) io.Writer {
+//		w, _ := logger.OpenFile("logs/" + tenant + ".log")
+//		return w
+//	}))
+//	log.ForTenant("acme").Info("invoice created")
+//
+// Tenant outputs are requested once per tenant and are not closed by the
+// logger.
+func (l *Logger) ForTenant(id string) *Logger {
+	child := l.withContext(mergeFields(l.fields, []contextField{{key: TenantFieldName, value: id}}, l.keyCollision))
+	if l.tenants == nil {
+		return child
+	}
+	output, writer := l.tenants.get(l, id)
+	if output == nil {
+		return child
+	}
+	child.cfg.Output = output
+	child.writer = writer
+	child.setBase(child.base.Output(writer))
+	return child
+}
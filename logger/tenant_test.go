@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestForTenant tests the tenant field and the routing of tenant outputs
+func TestForTenant(t *testing.T) {
+	var shared, acme bytes.Buffer
+	requested := 0
+	log := New(Config{
+		Level:  InfoLevel,
+		Output: &shared,
+		TenantOutput: func(tenant string) io.Writer {
+			requested++
+			if tenant == "acme" {
+				return &acme
+			}
+			return nil
+		},
+	})
+
+	log.ForTenant("globex").Info().Msg("shared entry")
+	assertLogContains(t, shared.String(), `"tenant":"globex"`, "info")
+	assertLogContains(t, shared.String(), "shared entry", "info")
+
+	log.ForTenant("acme").With().Str("user", "u1").Logger().Info().Msg("routed entry")
+	log.ForTenant("acme").Info().Msg("second entry")
+	if strings.Contains(shared.String(), "acme") {
+		t.Errorf("Expected the acme entries out of the shared output, got %s", shared.String())
+	}
+	lines := strings.Split(strings.TrimSpace(acme.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 acme entries, got %d: %s", len(lines), acme.String())
+	}
+	assertLogContains(t, lines[0], `"tenant":"acme"`, "info")
+	assertLogContains(t, lines[0], `"user":"u1"`, "info")
+	if requested != 2 {
+		t.Errorf("Expected the output of each tenant to be requested once, got %d requests", requested)
+	}
+
+	// Without TenantOutput every tenant writes to the shared output
+	shared.Reset()
+	New(Config{Level: InfoLevel, Output: &shared}).ForTenant("acme").Info().Msg("entry")
+	assertLogContains(t, shared.String(), `"tenant":"acme"`, "info")
+}
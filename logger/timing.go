@@ -0,0 +1,65 @@
+package logger
+
+import "time"
+
+// LatencyBucketFieldName is the field name of the bucket label added by Latency
+const LatencyBucketFieldName = "latency_bucket"
+
+// DefaultLatencyBuckets are the bucket boundaries used by Latency when none are given
+var DefaultLatencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Timer starts timing an operation and returns a function that adds the
+// elapsed time, measured with the logger clock, under key and writes the log
+// with msg. The fields added before Timer are kept:
+//
+//	done := log.Info().Str("op", "import").Timer("duration")
+//	defer done("import finished")
+//
+// The returned function must be called once. It is a no-op if the log is disabled.
+func (lb *LogBuilder) Timer(key string) func(msg string) {
+	if lb == nil {
+		return func(string) {}
+	}
+	start := lb.logger.clock()
+	return func(msg string) {
+		lb.Dur(key, lb.logger.clock().Sub(start)).send(msg, nil)
+	}
+}
+
+// Latency adds the duration under key and its bucket label, such as "<100ms",
+// under LatencyBucketFieldName, so latencies can be broken down by counting
+// log entries. The buckets are ascending upper bounds, DefaultLatencyBuckets
+// if none are given.
+func (lb *LogBuilder) Latency(key string, d time.Duration, buckets ...time.Duration) *LogBuilder {
+	if lb == nil {
+		return lb
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	lb.Dur(key, d)
+	lb.event.Str(lb.key(LatencyBucketFieldName), LatencyBucket(d, buckets))
+	return lb
+}
+
+// LatencyBucket returns the label of the first bucket d is below, such as
+// "<100ms", or ">=" the last bucket if d is above all of them
+func LatencyBucket(d time.Duration, buckets []time.Duration) string {
+	for _, b := range buckets {
+		if d < b {
+			return "<" + b.String()
+		}
+	}
+	if len(buckets) == 0 {
+		return ""
+	}
+	return ">=" + buckets[len(buckets)-1].String()
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestTimer tests logging the duration of an operation
+func TestTimer(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf), WithCaller(true))
+
+	func() {
+		done := log.Info().Str("op", "import").Timer("duration")
+		defer done("import finished")
+		time.Sleep(10 * time.Millisecond)
+	}()
+
+	var logData map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &logData); err != nil {
+		t.Fatalf("Could not parse log as JSON: %v", err)
+	}
+	if logData["message"] != "import finished" || logData["op"] != "import" {
+		t.Errorf("Unexpected entry: %s", buf.String())
+	}
+	if d, _ := logData["duration"].(float64); d < 10 {
+		t.Errorf("Expected a duration of at least 10ms, got %v", logData["duration"])
+	}
+	assertLogContains(t, buf.String(), "timing_test.go", "info")
+
+	// The duration is measured with the logger clock
+	buf.Reset()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clocked := NewWithOptions(WithOutput(&buf), WithClock(func() time.Time { return now }))
+	done := clocked.Info().Timer("duration")
+	now = now.Add(1500 * time.Millisecond)
+	done("clocked")
+	assertLogContains(t, buf.String(), `"duration":1500`, "info")
+
+	// Disabled logs return a no-op function
+	buf.Reset()
+	log.Debug().Timer("duration")("not logged")
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output, got: %s", buf.String())
+	}
+}
+
+// TestLatency tests the latency bucket label
+func TestLatency(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(WithOutput(&buf))
+
+	log.Info().Latency("duration", 75*time.Millisecond).Msg("request")
+	assertLogContains(t, buf.String(), `"latency_bucket":"<100ms"`, "info")
+	assertLogContains(t, buf.String(), `"duration":75`, "info")
+
+	buf.Reset()
+	log.Info().Latency("duration", 2*time.Second, 100*time.Millisecond, time.Second).Msg("request")
+	assertLogContains(t, buf.String(), `"latency_bucket":">=1s"`, "info")
+
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{5 * time.Millisecond, "<10ms"},
+		{10 * time.Millisecond, "<50ms"},
+		{999 * time.Millisecond, "<1s"},
+		{time.Minute, ">=5s"},
+	}
+	for _, tt := range tests {
+		if got := LatencyBucket(tt.d, DefaultLatencyBuckets); got != tt.want {
+			t.Errorf("LatencyBucket(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
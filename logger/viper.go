@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// SettingOutputs is the name of the list of outputs read by NewFromViper
+const SettingOutputs = "outputs"
+
+// ViperConfig is the part of *viper.Viper used by NewFromViper, so the logger
+// does not depend on viper
+type ViperConfig interface {
+	IsSet(key string) bool
+	GetString(key string) string
+	GetStringSlice(key string) []string
+}
+
+// NewFromViper creates a logger from the logging subsection key of a viper
+// configuration, or from the top level if key is empty:
+//
+//	logging:
+//	  level: warn
+//	  format: json
+//	  outputs: [stderr, /var/log/api.log]
+//	  caller: true
+//	  time_format: "2006-01-02T15:04:05Z07:00"
+//	  service_name: api
+//
+// Each output is "stdout", "stderr" or the path of a file opened for
+// appending, and every entry is written to all of them; the file setting
+// adds one more file. Rotation is not configured here: rotate the files
+// externally, such as with logrotate, and reopen them with FileWriter.Reopen.
+//
+// Missing settings keep their DefaultConfig value. It returns an error if a
+// setting is invalid or a log file cannot be opened.
+func NewFromViper(v ViperConfig, key string) (*Logger, error) {
+	name := func(setting string) string {
+		if key != "" {
+			return key + "." + setting
+		}
+		return setting
+	}
+	cfg, err := configFromSettings(func(setting string) (string, bool) {
+		if !v.IsSet(name(setting)) {
+			return "", false
+		}
+		return v.GetString(name(setting)), true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if v.IsSet(name(SettingOutputs)) {
+		var outputs multiOutput
+		if v.IsSet(name(SettingFile)) && v.GetString(name(SettingFile)) != "" {
+			outputs = append(outputs, cfg.Output)
+		}
+		for _, output := range v.GetStringSlice(name(SettingOutputs)) {
+			w, err := openOutput(output)
+			if err != nil {
+				outputs.Close()
+				return nil, err
+			}
+			outputs = append(outputs, w)
+		}
+		cfg.Output = outputs
+	}
+	return New(cfg), nil
+}
+
+// openOutput opens an output named in the configuration
+func openOutput(name string) (io.Writer, error) {
+	switch name {
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+	return OpenFile(name)
+}
+
+// multiOutput writes every entry to all its outputs, and closes the ones that
+// can be closed, except the standard output and error
+type multiOutput []io.Writer
+
+// Write implements io.Writer
+func (m multiOutput) Write(p []byte) (int, error) {
+	var errs []error
+	for _, w := range m {
+		if _, err := w.Write(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return len(p), nil
+}
+
+// Close implements io.Closer
+func (m multiOutput) Close() error {
+	var errs []error
+	for _, w := range m {
+		if closer, ok := w.(io.Closer); ok && closer != os.Stdout && closer != os.Stderr {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mapViper is a ViperConfig backed by a map of flattened keys
+type mapViper map[string]string
+
+func (m mapViper) IsSet(key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func (m mapViper) GetString(key string) string {
+	return m[key]
+}
+
+func (m mapViper) GetStringSlice(key string) []string {
+	return strings.Split(m[key], ",")
+}
+
+// TestNewFromViper tests building a logger from a viper subsection
+func TestNewFromViper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	v := mapViper{
+		"logging.level":        "warn",
+		"logging.file":         path,
+		"logging.caller":       "false",
+		"logging.service_name": "api",
+		"level":                "debug",
+	}
+	log, err := NewFromViper(v, "logging")
+	if err != nil {
+		t.Fatalf("NewFromViper failed: %v", err)
+	}
+	defer log.cfg.Output.(*FileWriter).Close()
+
+	log.Info().Msg("hidden")
+	log.Warn().Msg("written to file")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Could not read the log file: %v", err)
+	}
+	assertLogContains(t, string(data), "written to file", "warn")
+	assertLogContains(t, string(data), `"service":"api"`, "warn")
+	assertLogNotContains(t, string(data), "caller")
+
+	if _, err := NewFromViper(mapViper{"level": "loud"}, ""); err == nil || !strings.Contains(err.Error(), "loud") {
+		t.Errorf("Expected an invalid level error, got %v", err)
+	}
+	if _, err := NewFromViper(mapViper{"format": "xml"}, ""); err == nil {
+		t.Error("Expected an invalid format error")
+	}
+	if _, err := NewFromViper(mapViper{"caller": "maybe"}, ""); err == nil {
+		t.Error("Expected an invalid caller error")
+	}
+}
+
+// TestNewFromViperOutputs tests writing to a list of outputs
+func TestNewFromViperOutputs(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	log, err := NewFromViper(mapViper{"file": first, "outputs": second}, "")
+	if err != nil {
+		t.Fatalf("NewFromViper failed: %v", err)
+	}
+	log.SetLevel(InfoLevel)
+	log.InfoMsg("to every output")
+	if err := log.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	for _, path := range []string{first, second} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Could not read %s: %v", path, err)
+		}
+		assertLogContains(t, string(data), "to every output", "info")
+	}
+	if w, _ := openOutput("stdout"); w != os.Stdout {
+		t.Errorf("Expected stdout to be the standard output, got %v", w)
+	}
+
+	if _, err := NewFromViper(mapViper{"outputs": filepath.Join(dir, "missing", "app.log")}, ""); err == nil {
+		t.Error("Expected an error for an output that cannot be opened")
+	}
+}
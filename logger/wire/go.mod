@@ -0,0 +1,15 @@
+module github.com/jdroa1998/easy-logger/logger/wire
+
+go 1.24.1
+
+require (
+	github.com/google/wire v0.6.0
+	github.com/jdroa1998/easy-logger v0.0.0-20261016030613-2f95288b0e2e
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/rs/zerolog v1.33.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+)
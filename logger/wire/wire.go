@@ -0,0 +1,29 @@
+// Package loggerwire provides the logger to wire injectors. It is a separate
+// module, so only the applications using wire depend on it:
+//
+//	func initApp(cfg logger.Config) (*App, func(), error) {
+//		wire.Build(loggerwire.ProviderSet, newApp)
+//		return nil, nil, nil
+//	}
+package loggerwire
+
+import (
+	"github.com/google/wire"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// ProviderSet provides a *logger.Logger created from a logger.Config, with a
+// cleanup function closing it
+var ProviderSet = wire.NewSet(logger.ProvideLogger)
+
+// EnvProviderSet provides a *logger.Logger configured from environment
+// variables, like logger.NewFromEnv, with a cleanup function closing it
+var EnvProviderSet = wire.NewSet(ProvideLoggerFromEnv)
+
+// ProvideLoggerFromEnv creates a logger configured from environment variables
+// and a cleanup function closing it
+func ProvideLoggerFromEnv() (*logger.Logger, func()) {
+	l := logger.NewFromEnv()
+	return l, func() { l.Close() }
+}
@@ -0,0 +1,19 @@
+package loggerwire
+
+import (
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// TestProvideLoggerFromEnv tests the environment provider and its cleanup
+func TestProvideLoggerFromEnv(t *testing.T) {
+	t.Setenv(logger.EnvLogLevel, "warn")
+
+	log, cleanup := ProvideLoggerFromEnv()
+	if log == nil {
+		t.Fatal("Expected a logger")
+	}
+	log.Warn().Msg("running")
+	cleanup()
+}
@@ -0,0 +1,118 @@
+package loggertest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// Matcher reports whether an entry matches a condition
+type Matcher func(Entry) bool
+
+// Field matches entries with the field set to value. Values are compared
+// after a JSON round trip, so Field("count", 3) matches a logged int.
+func Field(key string, value any) Matcher {
+	expected := normalize(value)
+	return func(e Entry) bool {
+		actual, ok := e.Fields[key]
+		return ok && reflect.DeepEqual(actual, expected)
+	}
+}
+
+// HasField matches entries containing the field, whatever its value
+func HasField(key string) Matcher {
+	return func(e Entry) bool {
+		_, ok := e.Fields[key]
+		return ok
+	}
+}
+
+// CountAt returns the number of recorded entries at the given level
+func (tl *TestLogger) CountAt(level logger.Level) int {
+	count := 0
+	for _, e := range tl.Entries() {
+		if e.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+// Find returns the recorded entries at the given level whose message or
+// string fields contain text and that match all the matchers
+func (tl *TestLogger) Find(level logger.Level, text string, matchers ...Matcher) []Entry {
+	var found []Entry
+	for _, e := range tl.Entries() {
+		if e.Level == level && containsText(e, text) && matchAll(e, matchers) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// AssertLogged fails the test if no entry at the given level contains text in
+// its message or string fields and matches all the matchers
+func (tl *TestLogger) AssertLogged(t testing.TB, level logger.Level, text string, matchers ...Matcher) {
+	t.Helper()
+	if len(tl.Find(level, text, matchers...)) == 0 {
+		t.Errorf("Expected a %s entry containing %q, got entries:\n%s", level, text, tl.dump())
+	}
+}
+
+// AssertNotLogged fails the test if an entry at the given level contains text
+// in its message or string fields and matches all the matchers
+func (tl *TestLogger) AssertNotLogged(t testing.TB, level logger.Level, text string, matchers ...Matcher) {
+	t.Helper()
+	if found := tl.Find(level, text, matchers...); len(found) > 0 {
+		t.Errorf("Expected no %s entry containing %q, got %d:\n%s", level, text, len(found), tl.dump())
+	}
+}
+
+// containsText reports whether the message or a string field of e contains text
+func containsText(e Entry, text string) bool {
+	if strings.Contains(e.Message, text) {
+		return true
+	}
+	for _, v := range e.Fields {
+		if s, ok := v.(string); ok && strings.Contains(s, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAll reports whether e matches all the matchers
+func matchAll(e Entry, matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize converts a value to its JSON decoded representation
+func normalize(value any) any {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return value
+	}
+	return out
+}
+
+// dump formats the recorded entries for failure messages
+func (tl *TestLogger) dump() string {
+	var sb strings.Builder
+	for _, e := range tl.Entries() {
+		fields, _ := json.Marshal(e.Fields)
+		sb.WriteString("  " + e.Level.String() + " " + e.Message + " " + string(fields) + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package loggertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// EnvUpdateGolden is the environment variable that makes AssertGolden write
+// the golden files instead of comparing against them
+const EnvUpdateGolden = "UPDATE_GOLDEN"
+
+// Normalize rewrites JSON log output so it is stable across runs and machines:
+// keys are sorted, timestamps are removed and caller paths are reduced to the
+// file name and line. Lines that are not JSON objects are kept as is.
+func Normalize(output []byte) []byte {
+	var out bytes.Buffer
+	for _, line := range bytes.Split(bytes.TrimSpace(output), []byte("\n")) {
+		fields := map[string]any{}
+		if err := json.Unmarshal(line, &fields); err != nil {
+			out.Write(line)
+			out.WriteByte('\n')
+			continue
+		}
+		delete(fields, zerolog.TimestampFieldName)
+		if caller, ok := fields[zerolog.CallerFieldName].(string); ok {
+			fields[zerolog.CallerFieldName] = filepath.Base(caller)
+		}
+		normalized, _ := json.Marshal(fields)
+		out.Write(normalized)
+		out.WriteByte('\n')
+	}
+	return out.Bytes()
+}
+
+// AssertGolden compares the normalized output against the golden file and
+// fails the test with a line diff if they differ. Set UPDATE_GOLDEN=1 to
+// write the golden file from the output instead.
+func AssertGolden(t testing.TB, golden string, output []byte) {
+	t.Helper()
+	got := Normalize(output)
+
+	if os.Getenv(EnvUpdateGolden) != "" {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatalf("Could not create golden directory: %v", err)
+		}
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("Could not write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Could not read golden file (run with %s=1 to create it): %v", EnvUpdateGolden, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Log output does not match %s:\n%s", golden, lineDiff(string(want), string(got)))
+	}
+}
+
+// lineDiff returns the lines that differ between want and got
+func lineDiff(want, got string) string {
+	wantLines := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	gotLines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	var sb strings.Builder
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w == g {
+			continue
+		}
+		fmt.Fprintf(&sb, "line %d:\n", i+1)
+		if i < len(wantLines) {
+			fmt.Fprintf(&sb, "  - %s\n", w)
+		}
+		if i < len(gotLines) {
+			fmt.Fprintf(&sb, "  + %s\n", g)
+		}
+	}
+	return sb.String()
+}
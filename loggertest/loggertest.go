@@ -0,0 +1,44 @@
+// Package loggertest provides an in-memory logger that records parsed entries,
+// for testing code that logs.
+package loggertest
+
+import (
+	"io"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// Entry is a recorded log entry
+type Entry = logger.ObservedEntry
+
+// TestLogger is a Logger that records its entries in memory
+type TestLogger struct {
+	*logger.Logger
+	observer *logger.Observer
+}
+
+// New creates a TestLogger at trace level without caller information.
+// Options are applied on top of those defaults; the output cannot be changed.
+func New(opts ...logger.Option) *TestLogger {
+	obs := logger.NewObserver()
+	opts = append([]logger.Option{
+		logger.WithLevel(logger.TraceLevel),
+		logger.WithCaller(false),
+	}, opts...)
+	opts = append(opts, logger.WithOutput(io.Discard))
+
+	return &TestLogger{
+		Logger:   logger.NewWithOptions(opts...).WithObserver(obs),
+		observer: obs,
+	}
+}
+
+// Entries returns a copy of the recorded entries
+func (tl *TestLogger) Entries() []Entry {
+	return tl.observer.Entries()
+}
+
+// Reset discards the recorded entries
+func (tl *TestLogger) Reset() {
+	tl.observer.Reset()
+}
@@ -0,0 +1,117 @@
+package loggertest
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// TestRecordedEntries tests that entries are recorded and parsed
+func TestRecordedEntries(t *testing.T) {
+	tl := New()
+	if !tl.Enabled(logger.DebugLevel) {
+		t.Skip("Debug is compiled out by the easylogger_nodebug tag")
+	}
+
+	tl.Debug().Str("key", "value").Int("count", 3).Msg("debug message")
+	tl.Error().WithError(errors.New("connection refused")).Msg("request failed")
+
+	entries := tl.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Level != logger.DebugLevel || entries[0].Message != "debug message" {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].Fields["key"] != "value" || entries[0].Fields["count"] != 3.0 {
+		t.Errorf("Unexpected first entry fields: %v", entries[0].Fields)
+	}
+	if entries[0].Fields["service"] != "UNKNOWN-SERVICE" {
+		t.Errorf("Expected service field, got %v", entries[0].Fields)
+	}
+	if _, ok := entries[0].Fields["time"]; ok {
+		t.Error("Timestamp should not be included in fields")
+	}
+
+	if entries[1].Level != logger.ErrorLevel || entries[1].Fields["error"] != "connection refused" {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+
+	tl.Reset()
+	if len(tl.Entries()) != 0 {
+		t.Error("Reset should discard the recorded entries")
+	}
+}
+
+// TestAssertions tests the assertion helpers
+func TestAssertions(t *testing.T) {
+	tl := New()
+
+	tl.Info().Str("user", "alice").Int("attempt", 2).Msg("login")
+	tl.Error().WithError(errors.New("connection refused")).Msg("request failed")
+	tl.Error().Msg("another failure")
+
+	if tl.CountAt(logger.ErrorLevel) != 2 {
+		t.Errorf("Expected 2 error entries, got %d", tl.CountAt(logger.ErrorLevel))
+	}
+	if tl.CountAt(logger.WarnLevel) != 0 {
+		t.Errorf("Expected no warn entries, got %d", tl.CountAt(logger.WarnLevel))
+	}
+
+	tl.AssertLogged(t, logger.ErrorLevel, "connection refused")
+	tl.AssertLogged(t, logger.InfoLevel, "login", Field("user", "alice"), Field("attempt", 2))
+	tl.AssertNotLogged(t, logger.InfoLevel, "login", Field("user", "bob"))
+	tl.AssertNotLogged(t, logger.WarnLevel, "")
+
+	if len(tl.Find(logger.ErrorLevel, "", HasField("error"))) != 1 {
+		t.Error("Expected one error entry with an error field")
+	}
+
+	// Failed assertions are reported on the given testing.TB
+	mock := &testing.T{}
+	tl.AssertLogged(mock, logger.WarnLevel, "missing")
+	if !mock.Failed() {
+		t.Error("AssertLogged should fail when no entry matches")
+	}
+}
+
+// TestAssertGolden tests comparing normalized output against golden files
+func TestAssertGolden(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.New(logger.Config{Output: &buf, WithCaller: true})
+	log.Info().Str("user", "alice").Int("attempt", 2).Msg("login")
+
+	golden := filepath.Join(t.TempDir(), "login.golden")
+
+	t.Setenv(EnvUpdateGolden, "1")
+	AssertGolden(t, golden, buf.Bytes())
+
+	content, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Golden file should have been written: %v", err)
+	}
+	expected := `{"attempt":2,"caller":"loggertest_test.go:`
+	if !strings.HasPrefix(string(content), expected) {
+		t.Errorf("Expected normalized golden content, got %s", content)
+	}
+	if strings.Contains(string(content), `"time"`) {
+		t.Errorf("Timestamps should be removed, got %s", content)
+	}
+
+	t.Setenv(EnvUpdateGolden, "")
+	AssertGolden(t, golden, buf.Bytes())
+
+	buf.Reset()
+	log.Info().Str("user", "bob").Int("attempt", 2).Msg("login")
+	mock := &testing.T{}
+	AssertGolden(mock, golden, buf.Bytes())
+	if !mock.Failed() {
+		t.Error("AssertGolden should fail when the output differs")
+	}
+}
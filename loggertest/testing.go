@@ -0,0 +1,85 @@
+package loggertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// NewTesting creates a logger writing its entries to the test log with t.Log,
+// so they are shown only for failing tests or with go test -v, next to the
+// output of the test. Entries are written at trace level, without timestamp
+// and rendered like logger.FormatPlain, with the caller of each entry in its
+// "caller" field, since t.Log attributes the lines to the logger itself.
+// Options are applied on top of those defaults; the output and format cannot
+// be changed.
+//
+// With WithFailOnError, error and higher entries fail the test, surfacing
+// unexpected errors in the code under test. Fatal entries always fail the test
+// instead of exiting.
+func NewTesting(t testing.TB, opts ...logger.Option) *logger.Logger {
+	w := &testingWriter{t: t}
+	cfg := logger.DefaultConfig()
+	cfg.Level = logger.TraceLevel
+	cfg.DisableTimestamp = true
+	cfg.ExitFunc = func(code int) {
+		t.Helper()
+		t.Fatalf("logger: fatal entry, exit code %d", code)
+	}
+	cfg.Output = w
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	w.levelKey = cfg.LevelFieldName
+	if w.levelKey == "" {
+		w.levelKey = logger.DefaultLevelFieldName
+	}
+	cfg.Output = w
+	cfg.Format = logger.FormatJSON
+	cfg.Pretty = false
+	return logger.New(cfg)
+}
+
+// WithFailOnError makes a logger created with NewTesting fail the test on
+// error and higher entries. It has no effect on other loggers.
+func WithFailOnError(enabled bool) logger.Option {
+	return func(c *logger.Config) {
+		if w, ok := c.Output.(*testingWriter); ok {
+			w.failOnError = enabled
+		}
+	}
+}
+
+// testingWriter writes each JSON entry to the test log as a plain line
+type testingWriter struct {
+	t           testing.TB
+	failOnError bool
+	levelKey    string
+}
+
+// Write implements io.Writer
+func (w *testingWriter) Write(p []byte) (int, error) {
+	w.t.Helper()
+	var buf bytes.Buffer
+	logger.PlainFormatter{MessageWidth: -1}.Format(&buf).Write(p)
+	w.t.Log(strings.TrimSuffix(buf.String(), "\n"))
+	if w.failOnError && w.isError(p) {
+		w.t.Fail()
+	}
+	return len(p), nil
+}
+
+// isError reports whether the entry is at error severity or higher,
+// including critical and custom levels
+func (w *testingWriter) isError(p []byte) bool {
+	var entry map[string]any
+	if err := json.Unmarshal(p, &entry); err != nil {
+		return false
+	}
+	s, _ := entry[w.levelKey].(string)
+	level, err := logger.ParseLevel(s)
+	return err == nil && level.Severity() >= logger.ErrorLevel.Severity()
+}
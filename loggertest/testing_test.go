@@ -0,0 +1,82 @@
+package loggertest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jdroa1998/easy-logger/logger"
+)
+
+// fakeTB records the calls made by a testing logger
+type fakeTB struct {
+	testing.TB
+	logs   []string
+	failed bool
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Log(args ...any) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func (f *fakeTB) Fail() {
+	f.failed = true
+}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+	f.failed = true
+}
+
+// TestNewTesting tests writing entries to the test log
+func TestNewTesting(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	log := NewTesting(tb, logger.WithLevel(logger.InfoLevel))
+
+	log.Info().Int("id", 7).Msg("loaded")
+	log.Error().Msg("unexpected")
+	if len(tb.logs) != 2 {
+		t.Fatalf("Expected 2 lines, got %q", tb.logs)
+	}
+	if !strings.HasPrefix(tb.logs[0], "INFO     loaded service=UNKNOWN-SERVICE id=7 caller=") ||
+		!strings.Contains(tb.logs[0], "testing_test.go:") {
+		t.Errorf("Expected a plain line with the caller, got %q", tb.logs[0])
+	}
+	if tb.failed {
+		t.Error("Expected errors not to fail the test by default")
+	}
+
+	log.Fatal().Msg("stopped")
+	if !tb.failed || !strings.Contains(tb.logs[len(tb.logs)-1], "exit code 1") {
+		t.Errorf("Expected a fatal entry to fail the test, got %q", tb.logs)
+	}
+}
+
+// TestNewTestingFailOnError tests failing the test on error entries
+func TestNewTestingFailOnError(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	log := NewTesting(tb, WithFailOnError(true))
+
+	log.Warn().Msg("slow")
+	if tb.failed {
+		t.Error("Expected warnings not to fail the test")
+	}
+	log.Error().Msg("unexpected")
+	if !tb.failed {
+		t.Error("Expected the error to fail the test")
+	}
+
+	// Higher levels fail the test too, whatever the level key
+	tb = &fakeTB{TB: t}
+	log = NewTesting(tb, WithFailOnError(true), logger.WithFieldNames("severity", "", "", ""))
+	log.Warn().Msg("slow")
+	if tb.failed {
+		t.Error("Expected warnings not to fail the test")
+	}
+	log.Critical().Msg("down")
+	if !tb.failed {
+		t.Error("Expected the critical entry to fail the test")
+	}
+}